@@ -8,5 +8,6 @@ type Builder interface {
 	RegisterMiddleware(string, Middleware)
 	RegisterSerializer(string, Serializer)
 	RegisterTransportFactory(TransportFactory)
+	RegisterRetryStrategy(string, RetryStrategy)
 	Build() (Messenger, error)
 }
@@ -2,10 +2,12 @@ package api
 
 import (
 	"context"
+	"time"
 )
 
 type MessageBus interface {
 	Dispatch(context.Context, any, ...Stamp) (Envelope, error)
+	Request(context.Context, any, time.Duration, ...Stamp) (any, error)
 }
 
 type BusLocator interface {
@@ -0,0 +1,10 @@
+package api
+
+import "context"
+
+type FailedMessageStore interface {
+	List(context.Context, int) ([]Envelope, error)
+	Find(context.Context, string) (Envelope, error)
+	Retry(context.Context, ...string) error
+	Remove(context.Context, ...string) error
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"reflect"
+	"time"
 )
 
 type HandlerLocator interface {
@@ -12,12 +13,21 @@ type HandlerLocator interface {
 }
 
 type HandlerFunc struct {
-	Fn         reflect.Value
-	InputType  reflect.Type
-	HandlerStr string
-	BusName    string
+	Fn           reflect.Value
+	InputType    reflect.Type
+	ResultType   reflect.Type
+	HandlerStr   string
+	BusName      string
+	Batch        bool
+	BatchSize    int
+	BatchTimeout time.Duration
 }
 
 type MessageHandlerType interface {
 	GetBusName() string
 }
+
+type BatchHandler interface {
+	BatchSize() int
+	BatchTimeout() time.Duration
+}
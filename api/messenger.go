@@ -6,4 +6,5 @@ type Messenger interface {
 	Run(context.Context) error
 	GetDefaultBus() (MessageBus, error)
 	GetBusWith(string) (MessageBus, error)
+	GetFailedMessageStore() (FailedMessageStore, error)
 }
@@ -0,0 +1,18 @@
+package api
+
+import "time"
+
+type RetryStrategy interface {
+	ShouldRetry(attempt uint) (time.Duration, bool)
+}
+
+// PreviousDelayRetryStrategy derives the next delay from the previous one, as decorrelated
+// jitter does. The retry listener passes the delay of the last retry, 0 on the first one.
+type PreviousDelayRetryStrategy interface {
+	RetryStrategy
+	ShouldRetryAfter(attempt uint, previous time.Duration) (time.Duration, bool)
+}
+
+type RetryPolicyProvider interface {
+	RetryStrategy() RetryStrategy
+}
@@ -2,9 +2,12 @@ package api
 
 import (
 	"context"
+	"errors"
 	"reflect"
 )
 
+var ErrMessageHandedOff = errors.New("message handed off to retry or failure transport")
+
 type Transport interface {
 	Sender
 	Receiver
@@ -16,6 +19,16 @@ type Sender interface {
 	Send(context.Context, Envelope) error
 }
 
+type RequestSender interface {
+	Sender
+	Request(context.Context, Envelope) (Envelope, error)
+}
+
+type ReplySender interface {
+	Sender
+	Reply(ctx context.Context, request Envelope, reply Envelope) error
+}
+
 type Receiver interface {
 	Receive(context.Context, func(context.Context, Envelope) error) error
 }
@@ -29,6 +42,12 @@ type Producer interface {
 	Close() error
 }
 
+type RequestReplyProducer interface {
+	Producer
+	Request(context.Context, Envelope) (Envelope, error)
+	Reply(ctx context.Context, request Envelope, reply Envelope) error
+}
+
 type Consumer interface {
 	Consume(context.Context, func(context.Context, Envelope) error) error
 	Close() error
@@ -59,3 +78,14 @@ type TransportFactory interface {
 type RoutedMessage interface {
 	RoutingKey() string
 }
+
+type PartitionedMessage interface {
+	PartitionKey() string
+}
+
+type ListableTransport interface {
+	Transport
+	List(context.Context, int) ([]Envelope, error)
+	Find(context.Context, string) (Envelope, error)
+	Remove(context.Context, string) error
+}
@@ -0,0 +1,166 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/stamps"
+)
+
+const (
+	defaultListLimit = 50
+	tabPadding       = 2
+)
+
+type FailedMessagesCommand struct {
+	store api.FailedMessageStore
+	out   io.Writer
+}
+
+func NewFailedMessagesCommand(store api.FailedMessageStore, out io.Writer) *FailedMessagesCommand {
+	return &FailedMessagesCommand{
+		store: store,
+		out:   out,
+	}
+}
+
+func (c *FailedMessagesCommand) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: failed:list|failed:show|failed:retry|failed:remove [arguments]")
+	}
+
+	switch args[0] {
+	case "failed:list":
+		return c.list(ctx, args[1:])
+	case "failed:show":
+		return c.show(ctx, args[1:])
+	case "failed:retry":
+		return c.retry(ctx, args[1:])
+	case "failed:remove":
+		return c.remove(ctx, args[1:])
+	default:
+		return fmt.Errorf("unknown command '%s'", args[0])
+	}
+}
+
+func (c *FailedMessagesCommand) list(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("failed:list", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	limit := fs.Int("max", defaultListLimit, "maximum number of messages to list")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	envs, err := c.store.List(ctx, *limit)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, tabPadding, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tTRANSPORT\tORIGINAL TRANSPORT\tCLASS\tFAILED AT\tERROR")
+
+	for _, env := range envs {
+		idStamp, _ := envelope.LastStampOf[stamps.TransportMessageIDStamp](env)
+		failedStamp, _ := envelope.LastStampOf[stamps.SentToFailureTransportStamp](env)
+		errorStamp, _ := envelope.LastStampOf[stamps.ErrorDetailsStamp](env)
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			idStamp.ID,
+			idStamp.Transport,
+			failedStamp.OriginalTransport,
+			reflect.TypeOf(env.Message()).String(),
+			formatTime(errorStamp.FailedAt),
+			errorStamp.ErrorMessage,
+		)
+	}
+
+	return w.Flush()
+}
+
+func (c *FailedMessagesCommand) show(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: failed:show [<transport>:]<id>")
+	}
+
+	env, err := c.store.Find(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, tabPadding, ' ', 0)
+
+	_, _ = fmt.Fprintf(w, "ID\t%s\n", args[0])
+
+	if idStamp, ok := envelope.LastStampOf[stamps.TransportMessageIDStamp](env); ok {
+		_, _ = fmt.Fprintf(w, "Failure transport\t%s\n", idStamp.Transport)
+	}
+	_, _ = fmt.Fprintf(w, "Class\t%s\n", reflect.TypeOf(env.Message()).String())
+
+	if msgID, ok := envelope.LastStampOf[stamps.MessageIDStamp](env); ok {
+		_, _ = fmt.Fprintf(w, "Message ID\t%s\n", msgID.MessageID)
+	}
+
+	if failedStamp, ok := envelope.LastStampOf[stamps.SentToFailureTransportStamp](env); ok {
+		_, _ = fmt.Fprintf(w, "Original transport\t%s\n", failedStamp.OriginalTransport)
+	}
+
+	_, _ = fmt.Fprintf(w, "Message\t%+v\n", env.Message())
+
+	errorStamps := envelope.StampsOf[stamps.ErrorDetailsStamp](env)
+	_, _ = fmt.Fprintf(w, "Failures\t%d\n", len(errorStamps))
+
+	for _, errorStamp := range errorStamps {
+		_, _ = fmt.Fprintf(w, "  #%d\t%s\t%s\n",
+			errorStamp.RetryCount,
+			formatTime(errorStamp.FailedAt),
+			errorStamp.ErrorMessage,
+		)
+	}
+
+	return w.Flush()
+}
+
+func (c *FailedMessagesCommand) retry(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return errors.New("usage: failed:retry [<transport>:]<id> [<id>...]")
+	}
+
+	if err := c.store.Retry(ctx, ids...); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(c.out, "%d message(s) sent back to their original transport\n", len(ids))
+
+	return nil
+}
+
+func (c *FailedMessagesCommand) remove(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return errors.New("usage: failed:remove [<transport>:]<id> [<id>...]")
+	}
+
+	if err := c.store.Remove(ctx, ids...); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(c.out, "%d message(s) removed\n", len(ids))
+
+	return nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return t.Format(time.DateTime)
+}
@@ -0,0 +1,94 @@
+package command_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/command"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/failure"
+	"github.com/gerfey/messenger/core/stamps"
+	"github.com/gerfey/messenger/tests/helpers"
+	"github.com/gerfey/messenger/transport/inmemory"
+)
+
+func setupCommand(t *testing.T) (*command.FailedMessagesCommand, *bytes.Buffer, api.ListableTransport) {
+	t.Helper()
+
+	failed := inmemory.NewTransport("failed").(api.ListableTransport)
+	orders := inmemory.NewTransport("orders")
+
+	env := envelope.NewEnvelope(&helpers.TestMessage{ID: "1", Content: "test"}).
+		WithStamp(stamps.ErrorDetailsStamp{ErrorMessage: "first failure", FailedAt: time.Now()}).
+		WithStamp(stamps.ErrorDetailsStamp{ErrorMessage: "database is down", FailedAt: time.Now(), RetryCount: 1}).
+		WithStamp(stamps.SentToFailureTransportStamp{OriginalTransport: "orders"})
+	require.NoError(t, failed.Send(t.Context(), env))
+
+	store := failure.NewStore([]api.ListableTransport{failed}, map[string]api.Transport{"orders": orders})
+	out := &bytes.Buffer{}
+
+	return command.NewFailedMessagesCommand(store, out), out, failed
+}
+
+func TestFailedMessagesCommand_Run(t *testing.T) {
+	t.Run("list prints failed messages", func(t *testing.T) {
+		cmd, out, _ := setupCommand(t)
+
+		require.NoError(t, cmd.Run(t.Context(), []string{"failed:list"}))
+
+		assert.Contains(t, out.String(), "*helpers.TestMessage")
+		assert.Contains(t, out.String(), "database is down")
+	})
+
+	t.Run("show prints error history", func(t *testing.T) {
+		cmd, out, _ := setupCommand(t)
+
+		require.NoError(t, cmd.Run(t.Context(), []string{"failed:show", "1"}))
+
+		assert.Contains(t, out.String(), "orders")
+		assert.Contains(t, out.String(), "first failure")
+		assert.Contains(t, out.String(), "database is down")
+	})
+
+	t.Run("retry sends message back", func(t *testing.T) {
+		cmd, out, failed := setupCommand(t)
+
+		require.NoError(t, cmd.Run(t.Context(), []string{"failed:retry", "1"}))
+
+		assert.Contains(t, out.String(), "1 message(s) sent back")
+
+		remaining, err := failed.List(t.Context(), 0)
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+	})
+
+	t.Run("remove purges message", func(t *testing.T) {
+		cmd, _, failed := setupCommand(t)
+
+		require.NoError(t, cmd.Run(t.Context(), []string{"failed:remove", "1"}))
+
+		remaining, err := failed.List(t.Context(), 0)
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		cmd, _, _ := setupCommand(t)
+
+		require.Error(t, cmd.Run(t.Context(), []string{"failed:unknown"}))
+		require.Error(t, cmd.Run(t.Context(), nil))
+	})
+
+	t.Run("missing ids", func(t *testing.T) {
+		cmd, _, _ := setupCommand(t)
+
+		require.Error(t, cmd.Run(t.Context(), []string{"failed:show"}))
+		require.Error(t, cmd.Run(t.Context(), []string{"failed:retry"}))
+		require.Error(t, cmd.Run(t.Context(), []string{"failed:remove"}))
+	})
+}
@@ -0,0 +1,32 @@
+package ack
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Acknowledger settles a received message once its outcome is known. Handlers that
+// finish a message later, like batch handlers, call Defer and settle it through Ack.
+type Acknowledger struct {
+	ack      func(error)
+	once     sync.Once
+	deferred atomic.Bool
+}
+
+func New(ack func(error)) *Acknowledger {
+	return &Acknowledger{ack: ack}
+}
+
+func (a *Acknowledger) Defer() {
+	a.deferred.Store(true)
+}
+
+func (a *Acknowledger) Deferred() bool {
+	return a.deferred.Load()
+}
+
+func (a *Acknowledger) Ack(err error) {
+	a.once.Do(func() {
+		a.ack(err)
+	})
+}
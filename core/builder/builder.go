@@ -5,18 +5,22 @@ import (
 	"fmt"
 	"log/slog"
 	"reflect"
+	"sort"
 
 	"github.com/gerfey/messenger"
 	"github.com/gerfey/messenger/api"
 	"github.com/gerfey/messenger/core/bus"
+	"github.com/gerfey/messenger/core/circuitbreaker"
 	"github.com/gerfey/messenger/core/config"
 	"github.com/gerfey/messenger/core/event"
+	"github.com/gerfey/messenger/core/failure"
 	"github.com/gerfey/messenger/core/handler"
 	"github.com/gerfey/messenger/core/listener"
 	"github.com/gerfey/messenger/core/middleware"
 	"github.com/gerfey/messenger/core/middleware/implementation"
 	"github.com/gerfey/messenger/core/retry"
 	"github.com/gerfey/messenger/core/routing"
+	"github.com/gerfey/messenger/core/rpc"
 	"github.com/gerfey/messenger/core/serializer"
 	"github.com/gerfey/messenger/core/stamps"
 	"github.com/gerfey/messenger/transport"
@@ -27,6 +31,8 @@ import (
 	"github.com/gerfey/messenger/transport/sync"
 )
 
+const circuitBreakerMiddlewareName = "circuit_breaker"
+
 type Builder struct {
 	cfg               *config.MessengerConfig
 	resolver          api.TypeResolver
@@ -37,6 +43,7 @@ type Builder struct {
 	serializerLocator api.SerializerLocator
 	busLocator        api.BusLocator
 	eventDispatcher   api.EventDispatcher
+	retryStrategies   map[string]retry.Strategy
 	logger            *slog.Logger
 }
 
@@ -44,11 +51,12 @@ func NewBuilder(cfg *config.MessengerConfig, logger *slog.Logger) api.Builder {
 	resolver := NewResolver()
 	busLocator := bus.NewLocator()
 	serializerLocator := serializer.NewSerializerLocator()
+	eventDispatcher := event.NewEventDispatcher(logger)
 
 	transportFactory := transport.NewFactoryChain(
 		sync.NewTransportFactory(busLocator),
 		inmemory.NewTransportFactory(),
-		amqp.NewTransportFactory(),
+		amqp.NewTransportFactory(eventDispatcher),
 		kafka.NewTransportFactory(),
 		redis.NewTransportFactory(),
 	)
@@ -62,7 +70,8 @@ func NewBuilder(cfg *config.MessengerConfig, logger *slog.Logger) api.Builder {
 		middlewareLocator: middleware.NewMiddlewareLocator(),
 		serializerLocator: serializerLocator,
 		busLocator:        busLocator,
-		eventDispatcher:   event.NewEventDispatcher(logger),
+		eventDispatcher:   eventDispatcher,
+		retryStrategies:   make(map[string]retry.Strategy),
 		logger:            logger,
 	}
 }
@@ -93,6 +102,10 @@ func (b *Builder) RegisterTransportFactory(f api.TransportFactory) {
 	)
 }
 
+func (b *Builder) RegisterRetryStrategy(name string, strategy retry.Strategy) {
+	b.retryStrategies[name] = strategy
+}
+
 func (b *Builder) RegisterStamp(stamp any) {
 	b.resolver.RegisterStamp(stamp)
 }
@@ -104,8 +117,14 @@ func (b *Builder) RegisterListener(event any, listener any) {
 func (b *Builder) Build() (api.Messenger, error) {
 	for _, h := range b.handlersLocator.GetAll() {
 		b.resolver.Register(h.InputType.String(), h.InputType)
+
+		if h.ResultType != nil && h.ResultType.Kind() != reflect.Interface {
+			b.resolver.Register(h.ResultType.String(), h.ResultType)
+		}
 	}
 
+	b.resolver.RegisterMessage(&rpc.EmptyResult{})
+
 	b.registerStamps()
 
 	b.serializerLocator.Register("default.transport.serializer", serializer.NewSerializer(b.resolver))
@@ -118,6 +137,8 @@ func (b *Builder) Build() (api.Messenger, error) {
 }
 
 func (b *Builder) setupBuses() error {
+	b.registerCircuitBreakerMiddleware()
+
 	for name, cfg := range b.cfg.Buses {
 		var chain []api.Middleware
 
@@ -136,6 +157,7 @@ func (b *Builder) setupBuses() error {
 			chain,
 			implementation.NewSendMessageMiddleware(b.logger, b.senderLocator, b.eventDispatcher),
 		)
+		chain = append(chain, implementation.NewSendReplyMiddleware(b.logger, b.senderLocator))
 		chain = append(chain, implementation.NewHandleMessageMiddleware(b.logger, b.handlersLocator))
 
 		createNewBus := bus.NewBus(chain...)
@@ -149,6 +171,25 @@ func (b *Builder) setupBuses() error {
 	return nil
 }
 
+func (b *Builder) registerCircuitBreakerMiddleware() {
+	if _, err := b.middlewareLocator.Get(circuitBreakerMiddlewareName); err == nil {
+		return
+	}
+
+	b.middlewareLocator.Register(circuitBreakerMiddlewareName, implementation.NewCircuitBreakerMiddleware(
+		b.logger,
+		b.handlersLocator,
+		b.senderLocator,
+		b.eventDispatcher,
+		circuitbreaker.Config{
+			FailureThreshold: b.cfg.CircuitBreaker.FailureThreshold,
+			SuccessThreshold: b.cfg.CircuitBreaker.SuccessThreshold,
+			OpenTimeout:      b.cfg.CircuitBreaker.OpenTimeout,
+			HalfOpenMaxCalls: b.cfg.CircuitBreaker.HalfOpenMaxCalls,
+		},
+	))
+}
+
 func (b *Builder) createMessenger() (api.Messenger, error) {
 	router, err := b.setupRouting()
 	if err != nil {
@@ -165,14 +206,24 @@ func (b *Builder) createMessenger() (api.Messenger, error) {
 	}
 
 	b.setupFallbackTransports(transportNames)
-	b.setupRetryListeners(createdTransports)
+
+	messageStrategies, err := b.createMessageRetryStrategies()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = b.setupRetryListeners(createdTransports, messageStrategies); err != nil {
+		return nil, err
+	}
 
 	defaultBus, ok := b.busLocator.Get(b.cfg.DefaultBus)
 	if !ok {
 		return nil, fmt.Errorf("default_bus %q not found", defaultBus)
 	}
 
-	return messenger.NewMessenger(b.cfg.DefaultBus, manager, b.busLocator, router), nil
+	failedMessageStore := b.createFailedMessageStore(createdTransports)
+
+	return messenger.NewMessenger(b.cfg.DefaultBus, manager, b.busLocator, router, failedMessageStore), nil
 }
 
 func (b *Builder) setupRouting() (api.Router, error) {
@@ -266,35 +317,155 @@ func (b *Builder) setupFallbackTransports(transportNames []string) {
 	}
 }
 
-func (b *Builder) setupRetryListeners(createdTransports map[string]api.Transport) {
+func (b *Builder) setupRetryListeners(
+	createdTransports map[string]api.Transport,
+	messageStrategies map[reflect.Type]retry.Strategy,
+) error {
+	if b.cfg.FailureTransport != "" {
+		if _, exists := createdTransports[b.cfg.FailureTransport]; !exists {
+			return fmt.Errorf("failure transport '%s' not found", b.cfg.FailureTransport)
+		}
+	}
+
 	for name, tCfg := range b.cfg.Transports {
-		t := createdTransports[name]
-
-		if retryable, ok := t.(api.RetryableTransport); ok && tCfg.RetryStrategy != nil {
-			strategy := retry.NewMultiplierRetryStrategy(
-				tCfg.RetryStrategy.MaxRetries,
-				tCfg.RetryStrategy.Delay,
-				tCfg.RetryStrategy.Multiplier,
-				tCfg.RetryStrategy.MaxDelay,
-			)
+		var failureTransport api.Transport
+		if failureName := b.failureTransportName(tCfg); failureName != "" && failureName != name {
+			ft, exists := createdTransports[failureName]
+			if !exists {
+				return fmt.Errorf("failure transport '%s' for transport '%s' not found", failureName, name)
+			}
+
+			failureTransport = ft
+		}
+
+		retryable, ok := createdTransports[name].(api.RetryableTransport)
+		if !ok {
+			continue
+		}
 
-			var failureTransport api.Transport
-			if b.cfg.FailureTransport != "" {
-				if ft, exists := createdTransports[b.cfg.FailureTransport]; exists {
-					failureTransport = ft
-				}
+		// Without a transport strategy nothing is retried by default, but message strategies,
+		// recoverable errors and failure transport routing still apply.
+		var strategy retry.Strategy = retry.NewFixedRetryStrategy(0, 0)
+		if tCfg.RetryStrategy != nil {
+			var err error
+			if strategy, err = b.createRetryStrategy(tCfg.RetryStrategy); err != nil {
+				return fmt.Errorf("failed to create retry strategy for transport '%s': %w", name, err)
 			}
+		}
+
+		lst := listener.NewSendFailedMessageForRetryListener(b.logger, retryable, failureTransport, strategy).
+			WithMessageStrategies(messageStrategies)
+		b.eventDispatcher.AddListener(event.SendFailedMessageEvent{}, lst)
+	}
+
+	return nil
+}
+
+func (b *Builder) createMessageRetryStrategies() (map[reflect.Type]retry.Strategy, error) {
+	strategies := make(map[reflect.Type]retry.Strategy, len(b.cfg.RetryStrategies))
 
-			lst := listener.NewSendFailedMessageForRetryListener(b.logger, retryable, failureTransport, strategy)
-			b.eventDispatcher.AddListener(event.SendFailedMessageEvent{}, lst)
+	for msgTypeStr, strategyCfg := range b.cfg.RetryStrategies {
+		t, err := b.resolver.ResolveMessageType(msgTypeStr)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to resolve message type '%s' in retry strategies configuration: %w", msgTypeStr, err,
+			)
+		}
+
+		strategy, err := b.createRetryStrategy(&strategyCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create retry strategy for message type '%s': %w", msgTypeStr, err)
+		}
+
+		strategies[t] = strategy
+	}
+
+	return strategies, nil
+}
+
+func (b *Builder) createRetryStrategy(cfg *config.RetryStrategyConfig) (retry.Strategy, error) {
+	if cfg.Service != "" {
+		strategy, ok := b.retryStrategies[cfg.Service]
+		if !ok {
+			return nil, fmt.Errorf("retry strategy service %q not found", cfg.Service)
 		}
+
+		return strategy, nil
+	}
+
+	switch cfg.Type {
+	case "", "multiplier":
+		return retry.NewMultiplierRetryStrategy(cfg.MaxRetries, cfg.Delay, cfg.Multiplier, cfg.MaxDelay), nil
+	case "fixed":
+		return retry.NewFixedRetryStrategy(cfg.MaxRetries, cfg.Delay), nil
+	case "exponential":
+		if !retry.IsJitter(cfg.Jitter) {
+			return nil, fmt.Errorf("unknown retry jitter %q", cfg.Jitter)
+		}
+
+		return retry.NewExponentialRetryStrategy(cfg.MaxRetries, cfg.Delay, cfg.Multiplier, cfg.MaxDelay, cfg.Jitter), nil
+	case "schedule":
+		return retry.NewScheduleRetryStrategy(cfg.Schedule), nil
+	default:
+		return nil, fmt.Errorf("unknown retry strategy type %q", cfg.Type)
 	}
 }
 
+// createFailedMessageStore lists the failure transports that support it. Others, such as
+// AMQP queues or Kafka dead letter topics, still receive failed messages but are left to
+// the broker's own tooling.
+func (b *Builder) createFailedMessageStore(createdTransports map[string]api.Transport) api.FailedMessageStore {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, tCfg := range b.cfg.Transports {
+		if name := b.failureTransportName(tCfg); name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	if b.cfg.FailureTransport != "" && !seen[b.cfg.FailureTransport] {
+		names = append(names, b.cfg.FailureTransport)
+	}
+
+	sort.Strings(names)
+
+	failureTransports := make([]api.ListableTransport, 0, len(names))
+	for _, name := range names {
+		if listable, ok := createdTransports[name].(api.ListableTransport); ok {
+			failureTransports = append(failureTransports, listable)
+		}
+	}
+
+	if len(failureTransports) == 0 {
+		return nil
+	}
+
+	return failure.NewStore(failureTransports, createdTransports)
+}
+
+func (b *Builder) failureTransportName(tCfg config.TransportConfig) string {
+	if tCfg.FailureTransport != "" {
+		return tCfg.FailureTransport
+	}
+
+	return b.cfg.FailureTransport
+}
+
 func (b *Builder) registerStamps() {
 	b.resolver.RegisterStamp(stamps.BusNameStamp{})
 	b.resolver.RegisterStamp(stamps.RedeliveryStamp{})
 	b.resolver.RegisterStamp(stamps.MessageIDStamp{})
+	b.resolver.RegisterStamp(stamps.DelayStamp{})
+	b.resolver.RegisterStamp(stamps.ErrorDetailsStamp{})
+	b.resolver.RegisterStamp(stamps.SentToFailureTransportStamp{})
+	b.resolver.RegisterStamp(stamps.RoutingKeyStamp{})
+	b.resolver.RegisterStamp(stamps.TopicStamp{})
+	b.resolver.RegisterStamp(stamps.PriorityStamp{})
+	b.resolver.RegisterStamp(amqp.AmqpStamp{})
+	b.resolver.RegisterStamp(stamps.RequestStamp{})
+	b.resolver.RegisterStamp(stamps.ReplyStamp{})
 }
 
 func (b *Builder) createdSyncTransport(createdTransports map[string]api.Transport) {
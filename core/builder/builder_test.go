@@ -1,14 +1,21 @@
 package builder_test
 
 import (
+	"context"
+	"errors"
 	"log/slog"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	messengerpkg "github.com/gerfey/messenger"
+	"github.com/gerfey/messenger/api"
 	"github.com/gerfey/messenger/core/builder"
 	"github.com/gerfey/messenger/core/config"
+	"github.com/gerfey/messenger/core/retry"
 
 	"github.com/gerfey/messenger/core/stamps"
 	"github.com/gerfey/messenger/tests/helpers"
@@ -255,6 +262,103 @@ func TestBuilder_RegisterTransportFactory(t *testing.T) {
 	})
 }
 
+type recordingStrategy struct {
+	attempts atomic.Int32
+}
+
+func (s *recordingStrategy) ShouldRetry(uint) (time.Duration, bool) {
+	s.attempts.Add(1)
+
+	return 0, false
+}
+
+func TestBuilder_RegisterRetryStrategy(t *testing.T) {
+	newBuilder := func(retryCfg *config.RetryStrategyConfig) api.Builder {
+		cfg := newFailingConfig()
+		cfg.Transports["orders"] = config.TransportConfig{DSN: "in-memory://orders", RetryStrategy: retryCfg}
+
+		logger, _ := helpers.NewFakeLogger()
+
+		return builder.NewBuilder(cfg, logger)
+	}
+
+	t.Run("build with registered retry strategy service", func(t *testing.T) {
+		builderInstance := newBuilder(&config.RetryStrategyConfig{Service: "custom"})
+
+		strategy := &recordingStrategy{}
+		builderInstance.RegisterRetryStrategy("custom", strategy)
+
+		store := runFailing(t, builderInstance, &countingFailingHandler{err: errors.New("boom")})
+
+		require.Eventually(t, failedOnce(store), time.Second, 10*time.Millisecond)
+		assert.Equal(t, int32(1), strategy.attempts.Load())
+	})
+
+	t.Run("build fails with unknown retry strategy service", func(t *testing.T) {
+		builderInstance := newBuilder(&config.RetryStrategyConfig{Service: "missing"})
+		builderInstance.RegisterMessage(&helpers.TestMessage{})
+
+		_, err := builderInstance.Build()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "retry strategy service \"missing\" not found")
+	})
+
+	t.Run("build with built-in retry strategy types", func(t *testing.T) {
+		testCases := []struct {
+			retryCfg config.RetryStrategyConfig
+			calls    int32
+		}{
+			{retryCfg: config.RetryStrategyConfig{Type: "multiplier", MaxRetries: 1, Multiplier: 2}, calls: 2},
+			{retryCfg: config.RetryStrategyConfig{Type: "fixed", MaxRetries: 2}, calls: 3},
+			{
+				retryCfg: config.RetryStrategyConfig{
+					Type:       "exponential",
+					MaxRetries: 3,
+					Delay:      time.Millisecond,
+					Jitter:     retry.JitterFull,
+				},
+				calls: 4,
+			},
+			{
+				retryCfg: config.RetryStrategyConfig{
+					Type:       "schedule",
+					MaxRetries: 9,
+					Schedule:   []time.Duration{time.Millisecond, time.Millisecond},
+				},
+				calls: 3,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.retryCfg.Type, func(t *testing.T) {
+				handler := &countingFailingHandler{err: errors.New("boom")}
+				store := runFailing(t, newBuilder(&tc.retryCfg), handler)
+
+				require.Eventually(t, failedOnce(store), time.Second, 10*time.Millisecond)
+				assert.Equal(t, tc.calls, handler.calls.Load())
+			})
+		}
+	})
+
+	t.Run("build fails with unknown retry strategy type", func(t *testing.T) {
+		builderInstance := newBuilder(&config.RetryStrategyConfig{Type: "random"})
+		builderInstance.RegisterMessage(&helpers.TestMessage{})
+
+		_, err := builderInstance.Build()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown retry strategy type")
+	})
+
+	t.Run("build fails with unknown jitter", func(t *testing.T) {
+		builderInstance := newBuilder(&config.RetryStrategyConfig{Type: "exponential", Jitter: "random"})
+		builderInstance.RegisterMessage(&helpers.TestMessage{})
+
+		_, err := builderInstance.Build()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown retry jitter \"random\"")
+	})
+}
+
 func TestBuilder_Build_Errors(t *testing.T) {
 	t.Run("build fails with unknown message type in routing", func(t *testing.T) {
 		cfg := &config.MessengerConfig{
@@ -455,3 +559,270 @@ func TestBuilder_Build_Success(t *testing.T) {
 		require.NotNil(t, messenger)
 	})
 }
+
+func TestBuilder_FailureTransports(t *testing.T) {
+	newConfig := func(paymentsFailure string) *config.MessengerConfig {
+		return &config.MessengerConfig{
+			DefaultBus:        "default",
+			DefaultSerializer: "default.transport.serializer",
+			FailureTransport:  "failed",
+			Buses: map[string]config.BusConfig{
+				"default": {},
+			},
+			Transports: map[string]config.TransportConfig{
+				"payments": {
+					DSN:              "in-memory://payments",
+					FailureTransport: paymentsFailure,
+					RetryStrategy:    &config.RetryStrategyConfig{Type: "fixed", MaxRetries: 1},
+				},
+				"notifications": {
+					DSN:           "in-memory://notifications",
+					RetryStrategy: &config.RetryStrategyConfig{Type: "fixed", MaxRetries: 1},
+				},
+				"failed":          {DSN: "in-memory://failed"},
+				"failed_payments": {DSN: "in-memory://failed_payments"},
+			},
+		}
+	}
+
+	t.Run("build with per-transport failure transport", func(t *testing.T) {
+		logger, _ := helpers.NewFakeLogger()
+		builderInstance := builder.NewBuilder(newConfig("failed_payments"), logger)
+
+		messenger, err := builderInstance.Build()
+		require.NoError(t, err)
+
+		store, err := messenger.GetFailedMessageStore()
+		require.NoError(t, err)
+		require.NotNil(t, store)
+	})
+
+	t.Run("build fails with unknown per-transport failure transport", func(t *testing.T) {
+		logger, _ := helpers.NewFakeLogger()
+		builderInstance := builder.NewBuilder(newConfig("missing"), logger)
+
+		_, err := builderInstance.Build()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failure transport 'missing' for transport 'payments' not found")
+	})
+
+	t.Run("build skips non-listable failure transport in the store", func(t *testing.T) {
+		cfg := newConfig("failed_payments")
+		cfg.Transports["failed_payments"] = config.TransportConfig{DSN: "test://failed_payments"}
+
+		logger, _ := helpers.NewFakeLogger()
+		builderInstance := builder.NewBuilder(cfg, logger)
+		builderInstance.RegisterTransportFactory(&helpers.TestTransportFactory{
+			TransportName: "test_transport",
+			Transport:     &helpers.TestTransport{TransportName: "failed_payments"},
+		})
+
+		messenger, err := builderInstance.Build()
+		require.NoError(t, err)
+
+		store, err := messenger.GetFailedMessageStore()
+		require.NoError(t, err)
+
+		_, err = store.Find(context.Background(), "failed_payments:1")
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "in 'failed_payments'")
+	})
+}
+
+func TestBuilder_MessageRetryStrategies(t *testing.T) {
+	newConfig := func(msgType string, retryCfg config.RetryStrategyConfig) *config.MessengerConfig {
+		return &config.MessengerConfig{
+			DefaultBus:        "default",
+			DefaultSerializer: "default.transport.serializer",
+			Buses: map[string]config.BusConfig{
+				"default": {},
+			},
+			Transports: map[string]config.TransportConfig{
+				"inmemory": {
+					DSN:           "in-memory://test",
+					RetryStrategy: &config.RetryStrategyConfig{Type: "fixed", MaxRetries: 1},
+				},
+			},
+			RetryStrategies: map[string]config.RetryStrategyConfig{
+				msgType: retryCfg,
+			},
+		}
+	}
+
+	t.Run("build with retry strategy per message type", func(t *testing.T) {
+		logger, _ := helpers.NewFakeLogger()
+		builderInstance := builder.NewBuilder(newConfig("*helpers.TestMessage", config.RetryStrategyConfig{
+			Type:     "schedule",
+			Schedule: []time.Duration{time.Second, time.Minute},
+		}), logger)
+		builderInstance.RegisterMessage(&helpers.TestMessage{})
+
+		_, err := builderInstance.Build()
+		require.NoError(t, err)
+	})
+
+	t.Run("build fails with unknown message type", func(t *testing.T) {
+		logger, _ := helpers.NewFakeLogger()
+		builderInstance := builder.NewBuilder(newConfig("unknown.Message", config.RetryStrategyConfig{}), logger)
+
+		_, err := builderInstance.Build()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "retry strategies configuration")
+	})
+
+	t.Run("build fails with invalid retry strategy", func(t *testing.T) {
+		logger, _ := helpers.NewFakeLogger()
+		builderInstance := builder.NewBuilder(newConfig("*helpers.TestMessage", config.RetryStrategyConfig{
+			Type: "random",
+		}), logger)
+		builderInstance.RegisterMessage(&helpers.TestMessage{})
+
+		_, err := builderInstance.Build()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create retry strategy for message type")
+	})
+}
+
+func TestBuilder_CircuitBreakerMiddleware(t *testing.T) {
+	t.Run("build bus with built-in circuit breaker middleware", func(t *testing.T) {
+		cfg := &config.MessengerConfig{
+			DefaultBus:        "default",
+			DefaultSerializer: "default.transport.serializer",
+			Buses: map[string]config.BusConfig{
+				"default": {
+					Middleware: []string{"circuit_breaker"},
+				},
+			},
+			CircuitBreaker: config.CircuitBreakerConfig{
+				FailureThreshold: 3,
+				OpenTimeout:      time.Second,
+			},
+		}
+		logger, _ := helpers.NewFakeLogger()
+		builderInstance := builder.NewBuilder(cfg, logger)
+
+		messenger, err := builderInstance.Build()
+		require.NoError(t, err)
+		require.NotNil(t, messenger)
+	})
+}
+
+type countingFailingHandler struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (h *countingFailingHandler) Handle(_ context.Context, _ *helpers.TestMessage) error {
+	h.calls.Add(1)
+
+	return h.err
+}
+
+func newFailingConfig() *config.MessengerConfig {
+	return &config.MessengerConfig{
+		DefaultBus:        "default",
+		DefaultSerializer: "default.transport.serializer",
+		FailureTransport:  "failed",
+		Buses: map[string]config.BusConfig{
+			"default": {},
+		},
+		Transports: map[string]config.TransportConfig{
+			"orders": {DSN: "in-memory://orders"},
+			"failed": {DSN: "in-memory://failed"},
+		},
+		Routing: map[string]string{
+			"*helpers.TestMessage": "orders",
+		},
+	}
+}
+
+func runFailing(
+	t *testing.T,
+	builderInstance api.Builder,
+	handler *countingFailingHandler,
+) api.FailedMessageStore {
+	t.Helper()
+
+	require.NoError(t, builderInstance.RegisterHandler(handler))
+
+	messenger, err := builderInstance.Build()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	go func() {
+		_ = messenger.Run(ctx)
+	}()
+
+	bus, err := messenger.GetDefaultBus()
+	require.NoError(t, err)
+
+	_, err = bus.Dispatch(t.Context(), &helpers.TestMessage{Content: "order"})
+	require.NoError(t, err)
+
+	store, err := messenger.GetFailedMessageStore()
+	require.NoError(t, err)
+
+	return store
+}
+
+func failedOnce(store api.FailedMessageStore) func() bool {
+	return func() bool {
+		envs, err := store.List(context.Background(), 0)
+
+		return err == nil && len(envs) == 1
+	}
+}
+
+func TestBuilder_RetryListenerWiring(t *testing.T) {
+	newBuilder := func(cfg *config.MessengerConfig) api.Builder {
+		logger, _ := helpers.NewFakeLogger()
+
+		return builder.NewBuilder(cfg, logger)
+	}
+
+	t.Run("failure transport applies without a retry strategy", func(t *testing.T) {
+		handler := &countingFailingHandler{err: errors.New("boom")}
+		store := runFailing(t, newBuilder(newFailingConfig()), handler)
+
+		require.Eventually(t, failedOnce(store), time.Second, 10*time.Millisecond)
+		assert.Equal(t, int32(1), handler.calls.Load())
+	})
+
+	t.Run("message retry strategy applies without a transport strategy", func(t *testing.T) {
+		cfg := newFailingConfig()
+		cfg.RetryStrategies = map[string]config.RetryStrategyConfig{
+			"*helpers.TestMessage": {Type: "fixed", MaxRetries: 2},
+		}
+
+		handler := &countingFailingHandler{err: errors.New("boom")}
+		store := runFailing(t, newBuilder(cfg), handler)
+
+		require.Eventually(t, failedOnce(store), time.Second, 10*time.Millisecond)
+		assert.Equal(t, int32(3), handler.calls.Load())
+	})
+
+	t.Run("recoverable errors are retried without a transport strategy", func(t *testing.T) {
+		handler := &countingFailingHandler{err: messengerpkg.RecoverableAfter(errors.New("busy"), time.Millisecond)}
+		runFailing(t, newBuilder(newFailingConfig()), handler)
+
+		require.Eventually(t, func() bool {
+			return handler.calls.Load() > 1
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("build fails with unknown failure transport", func(t *testing.T) {
+		cfg := newFailingConfig()
+		cfg.FailureTransport = "missing"
+
+		logger, _ := helpers.NewFakeLogger()
+		builderInstance := builder.NewBuilder(cfg, logger)
+		builderInstance.RegisterMessage(&helpers.TestMessage{})
+
+		_, err := builderInstance.Build()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failure transport 'missing' not found")
+	})
+}
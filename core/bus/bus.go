@@ -2,11 +2,18 @@ package bus
 
 import (
 	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/gerfey/messenger/api"
 	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/stamps"
 )
 
+var ErrNoReply = errors.New("request was not handled and produced no reply")
+
 type Bus struct {
 	middlewareChain []api.Middleware
 }
@@ -30,6 +37,28 @@ func (b *Bus) Dispatch(ctx context.Context, msg any, st ...api.Stamp) (api.Envel
 	return b.buildChain()(ctx, env)
 }
 
+func (b *Bus) Request(ctx context.Context, msg any, timeout time.Duration, st ...api.Stamp) (any, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	st = append(st[:len(st):len(st)], stamps.RequestStamp{CorrelationID: uuid.New().String()})
+
+	env, err := b.Dispatch(ctx, msg, st...)
+	if err != nil {
+		return nil, err
+	}
+
+	handledStamp, ok := envelope.LastStampOf[stamps.HandledStamp](env)
+	if !ok {
+		return nil, ErrNoReply
+	}
+
+	return handledStamp.Result, nil
+}
+
 func (b *Bus) buildChain() api.NextFunc {
 	handler := func(_ context.Context, env api.Envelope) (api.Envelope, error) {
 		return env, nil
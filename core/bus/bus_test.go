@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/gerfey/messenger/api"
 	"github.com/gerfey/messenger/core/bus"
 	"github.com/gerfey/messenger/core/envelope"
 	"github.com/gerfey/messenger/core/stamps"
@@ -170,3 +172,47 @@ func TestMiddlewareExecution(t *testing.T) {
 		assert.Equal(t, []string{"first", "second", "third"}, executionOrder)
 	})
 }
+
+type replyingMiddleware struct {
+	result      any
+	request     stamps.RequestStamp
+	hasDeadline bool
+}
+
+func (m *replyingMiddleware) Handle(ctx context.Context, env api.Envelope, next api.NextFunc) (api.Envelope, error) {
+	m.request, _ = envelope.LastStampOf[stamps.RequestStamp](env)
+	_, m.hasDeadline = ctx.Deadline()
+
+	return next(ctx, env.WithStamp(stamps.HandledStamp{Handler: "handler", Result: m.result}))
+}
+
+func TestBus_Request(t *testing.T) {
+	t.Run("returns handler result", func(t *testing.T) {
+		middleware := &replyingMiddleware{result: "pong"}
+		messageBus := bus.NewBus(middleware)
+
+		result, err := messageBus.Request(t.Context(), &helpers.TestMessage{Content: "ping"}, time.Second)
+
+		require.NoError(t, err)
+		assert.Equal(t, "pong", result)
+		assert.NotEmpty(t, middleware.request.CorrelationID)
+		assert.True(t, middleware.hasDeadline)
+	})
+
+	t.Run("returns error without reply", func(t *testing.T) {
+		messageBus := bus.NewBus()
+
+		_, err := messageBus.Request(t.Context(), &helpers.TestMessage{Content: "ping"}, 0)
+
+		require.ErrorIs(t, err, bus.ErrNoReply)
+	})
+
+	t.Run("returns dispatch error", func(t *testing.T) {
+		middlewareErr := errors.New("middleware failed")
+		messageBus := bus.NewBus(&helpers.ErrorMiddleware{Error: middlewareErr})
+
+		_, err := messageBus.Request(t.Context(), &helpers.TestMessage{Content: "ping"}, time.Second)
+
+		require.ErrorIs(t, err, middlewareErr)
+	})
+}
@@ -0,0 +1,204 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultSuccessThreshold = 1
+	defaultOpenTimeout      = 30 * time.Second
+	defaultHalfOpenMaxCalls = 1
+)
+
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State string
+
+const (
+	StateClosed   State = "closed"
+	StateOpen     State = "open"
+	StateHalfOpen State = "half-open"
+)
+
+type Config struct {
+	FailureThreshold uint
+	SuccessThreshold uint
+	OpenTimeout      time.Duration
+	HalfOpenMaxCalls uint
+}
+
+type Breaker struct {
+	mu            sync.Mutex
+	name          string
+	config        Config
+	state         State
+	failures      uint
+	successes     uint
+	halfOpenCalls uint
+	openedAt      time.Time
+	onStateChange func(ctx context.Context, name string, from, to State)
+	now           func() time.Time
+}
+
+func NewBreaker(name string, config Config, onStateChange func(ctx context.Context, name string, from, to State)) *Breaker {
+	return &Breaker{
+		name:          name,
+		config:        withDefaults(config),
+		state:         StateClosed,
+		onStateChange: onStateChange,
+		now:           time.Now,
+	}
+}
+
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+func (b *Breaker) Allow(ctx context.Context) (time.Duration, bool) {
+	b.mu.Lock()
+
+	var (
+		change  *stateChange
+		wait    time.Duration
+		allowed = true
+	)
+
+	switch b.state {
+	case StateOpen:
+		remaining := b.config.OpenTimeout - b.now().Sub(b.openedAt)
+		if remaining > 0 {
+			wait, allowed = remaining, false
+
+			break
+		}
+
+		change = b.transition(StateHalfOpen)
+		b.halfOpenCalls = 1
+	case StateHalfOpen:
+		if b.halfOpenCalls >= b.config.HalfOpenMaxCalls {
+			wait, allowed = b.config.OpenTimeout, false
+
+			break
+		}
+
+		b.halfOpenCalls++
+	case StateClosed:
+	}
+
+	b.mu.Unlock()
+	b.notify(ctx, change)
+
+	return wait, allowed
+}
+
+func (b *Breaker) Success(ctx context.Context) {
+	b.mu.Lock()
+
+	var change *stateChange
+
+	switch b.state {
+	case StateHalfOpen:
+		b.successes++
+		if b.halfOpenCalls > 0 {
+			b.halfOpenCalls--
+		}
+
+		if b.successes >= b.config.SuccessThreshold {
+			change = b.transition(StateClosed)
+		}
+	case StateClosed:
+		b.failures = 0
+	case StateOpen:
+	}
+
+	b.mu.Unlock()
+	b.notify(ctx, change)
+}
+
+func (b *Breaker) Failure(ctx context.Context) {
+	b.mu.Lock()
+
+	var change *stateChange
+
+	switch b.state {
+	case StateHalfOpen:
+		change = b.transition(StateOpen)
+	case StateClosed:
+		b.failures++
+		if b.failures >= b.config.FailureThreshold {
+			change = b.transition(StateOpen)
+		}
+	case StateOpen:
+	}
+
+	b.mu.Unlock()
+	b.notify(ctx, change)
+}
+
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen && b.halfOpenCalls > 0 {
+		b.halfOpenCalls--
+	}
+}
+
+type stateChange struct {
+	from State
+	to   State
+}
+
+func (b *Breaker) transition(to State) *stateChange {
+	change := &stateChange{from: b.state, to: to}
+
+	b.state = to
+	b.failures = 0
+	b.successes = 0
+	b.halfOpenCalls = 0
+
+	if to == StateOpen {
+		b.openedAt = b.now()
+	}
+
+	return change
+}
+
+func (b *Breaker) notify(ctx context.Context, change *stateChange) {
+	if change == nil || b.onStateChange == nil {
+		return
+	}
+
+	b.onStateChange(ctx, b.name, change.from, change.to)
+}
+
+func withDefaults(config Config) Config {
+	if config.FailureThreshold == 0 {
+		config.FailureThreshold = defaultFailureThreshold
+	}
+
+	if config.SuccessThreshold == 0 {
+		config.SuccessThreshold = defaultSuccessThreshold
+	}
+
+	if config.OpenTimeout == 0 {
+		config.OpenTimeout = defaultOpenTimeout
+	}
+
+	if config.HalfOpenMaxCalls == 0 {
+		config.HalfOpenMaxCalls = defaultHalfOpenMaxCalls
+	}
+
+	return config
+}
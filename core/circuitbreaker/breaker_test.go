@@ -0,0 +1,106 @@
+package circuitbreaker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/core/circuitbreaker"
+)
+
+type transition struct {
+	from circuitbreaker.State
+	to   circuitbreaker.State
+}
+
+func newBreaker(config circuitbreaker.Config) (*circuitbreaker.Breaker, *[]transition) {
+	transitions := make([]transition, 0)
+
+	b := circuitbreaker.NewBreaker("test", config, func(_ context.Context, _ string, from, to circuitbreaker.State) {
+		transitions = append(transitions, transition{from: from, to: to})
+	})
+
+	return b, &transitions
+}
+
+func TestBreaker(t *testing.T) {
+	t.Run("opens after failure threshold", func(t *testing.T) {
+		b, transitions := newBreaker(circuitbreaker.Config{FailureThreshold: 2, OpenTimeout: time.Minute})
+
+		b.Failure(t.Context())
+		assert.Equal(t, circuitbreaker.StateClosed, b.State())
+
+		b.Failure(t.Context())
+		assert.Equal(t, circuitbreaker.StateOpen, b.State())
+
+		wait, ok := b.Allow(t.Context())
+		assert.False(t, ok)
+		assert.Greater(t, wait, 59*time.Second)
+
+		assert.Equal(t, []transition{{circuitbreaker.StateClosed, circuitbreaker.StateOpen}}, *transitions)
+	})
+
+	t.Run("success resets failure count", func(t *testing.T) {
+		b, _ := newBreaker(circuitbreaker.Config{FailureThreshold: 2})
+
+		b.Failure(t.Context())
+		b.Success(t.Context())
+		b.Failure(t.Context())
+
+		assert.Equal(t, circuitbreaker.StateClosed, b.State())
+	})
+
+	t.Run("closes after successful half-open call", func(t *testing.T) {
+		b, transitions := newBreaker(circuitbreaker.Config{FailureThreshold: 1, OpenTimeout: 10 * time.Millisecond})
+
+		b.Failure(t.Context())
+		time.Sleep(20 * time.Millisecond)
+
+		_, ok := b.Allow(t.Context())
+		require.True(t, ok)
+		assert.Equal(t, circuitbreaker.StateHalfOpen, b.State())
+
+		_, ok = b.Allow(t.Context())
+		assert.False(t, ok, "only one trial call is allowed in half-open state")
+
+		b.Success(t.Context())
+		assert.Equal(t, circuitbreaker.StateClosed, b.State())
+
+		assert.Equal(t, []transition{
+			{circuitbreaker.StateClosed, circuitbreaker.StateOpen},
+			{circuitbreaker.StateOpen, circuitbreaker.StateHalfOpen},
+			{circuitbreaker.StateHalfOpen, circuitbreaker.StateClosed},
+		}, *transitions)
+	})
+
+	t.Run("reopens after failed half-open call", func(t *testing.T) {
+		b, _ := newBreaker(circuitbreaker.Config{FailureThreshold: 1, OpenTimeout: 10 * time.Millisecond})
+
+		b.Failure(t.Context())
+		time.Sleep(20 * time.Millisecond)
+
+		_, ok := b.Allow(t.Context())
+		require.True(t, ok)
+
+		b.Failure(t.Context())
+		assert.Equal(t, circuitbreaker.StateOpen, b.State())
+	})
+
+	t.Run("release frees half-open slot", func(t *testing.T) {
+		b, _ := newBreaker(circuitbreaker.Config{FailureThreshold: 1, OpenTimeout: 10 * time.Millisecond})
+
+		b.Failure(t.Context())
+		time.Sleep(20 * time.Millisecond)
+
+		_, ok := b.Allow(t.Context())
+		require.True(t, ok)
+
+		b.Release()
+
+		_, ok = b.Allow(t.Context())
+		assert.True(t, ok)
+	})
+}
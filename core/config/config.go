@@ -11,12 +11,14 @@ const (
 )
 
 type MessengerConfig struct {
-	DefaultBus        string                     `yaml:"default_bus"        default:"default"`
-	DefaultSerializer string                     `yaml:"default_serializer" default:"default.transport.serializer"`
-	FailureTransport  string                     `yaml:"failure_transport"`
-	Buses             map[string]BusConfig       `yaml:"buses"`
-	Transports        map[string]TransportConfig `yaml:"transports"`
-	Routing           map[string]string          `yaml:"routing"`
+	DefaultBus        string                         `yaml:"default_bus"        default:"default"`
+	DefaultSerializer string                         `yaml:"default_serializer" default:"default.transport.serializer"`
+	FailureTransport  string                         `yaml:"failure_transport"`
+	Buses             map[string]BusConfig           `yaml:"buses"`
+	Transports        map[string]TransportConfig     `yaml:"transports"`
+	Routing           map[string]string              `yaml:"routing"`
+	RetryStrategies   map[string]RetryStrategyConfig `yaml:"retry_strategies"`
+	CircuitBreaker    CircuitBreakerConfig           `yaml:"circuit_breaker"`
 }
 
 type BusConfig struct {
@@ -24,17 +26,29 @@ type BusConfig struct {
 }
 
 type TransportConfig struct {
-	DSN           string               `yaml:"dsn"`
-	Serializer    string               `yaml:"serializer"`
-	RetryStrategy *RetryStrategyConfig `yaml:"retry_strategy"`
-	Options       map[string]any       `yaml:"options"`
+	DSN              string               `yaml:"dsn"`
+	Serializer       string               `yaml:"serializer"`
+	FailureTransport string               `yaml:"failure_transport"`
+	RetryStrategy    *RetryStrategyConfig `yaml:"retry_strategy"`
+	Options          map[string]any       `yaml:"options"`
 }
 
 type RetryStrategyConfig struct {
-	MaxRetries uint          `yaml:"max_retries"`
-	Delay      time.Duration `yaml:"delay"`
-	Multiplier float64       `yaml:"multiplier"`
-	MaxDelay   time.Duration `yaml:"max_delay"`
+	Service    string          `yaml:"service"`
+	Type       string          `yaml:"type"` // multiplier, fixed, exponential, schedule
+	MaxRetries uint            `yaml:"max_retries"`
+	Delay      time.Duration   `yaml:"delay"`
+	Multiplier float64         `yaml:"multiplier"`
+	MaxDelay   time.Duration   `yaml:"max_delay"`
+	Jitter     string          `yaml:"jitter"` // none, full, equal, decorrelated
+	Schedule   []time.Duration `yaml:"schedule"`
+}
+
+type CircuitBreakerConfig struct {
+	FailureThreshold uint          `yaml:"failure_threshold"   default:"5"`
+	SuccessThreshold uint          `yaml:"success_threshold"   default:"1"`
+	OpenTimeout      time.Duration `yaml:"open_timeout"        default:"30s"`
+	HalfOpenMaxCalls uint          `yaml:"half_open_max_calls" default:"1"`
 }
 
 type SerializedEnvelope struct {
@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -172,6 +173,34 @@ transports:
 		assert.Equal(t, uint(3), cfg.Transports["amqp"].RetryStrategy.MaxRetries)
 		assert.InDelta(t, float64(2), cfg.Transports["amqp"].RetryStrategy.Multiplier, 0.001)
 	})
+
+	t.Run("parse yaml with schedule retry strategy", func(t *testing.T) {
+		content := []byte(`
+transports:
+  amqp:
+    dsn: amqp://localhost
+    retry_strategy:
+      type: schedule
+      schedule: [1s, 10s, 1m, 10m]
+  redis:
+    dsn: redis://localhost
+    retry_strategy:
+      service: my_strategy
+`)
+		var cfg config.MessengerConfig
+		err := parser.Parse(content, &cfg)
+
+		require.NoError(t, err)
+		require.NotNil(t, cfg.Transports["amqp"].RetryStrategy)
+		assert.Equal(t, "schedule", cfg.Transports["amqp"].RetryStrategy.Type)
+		assert.Equal(
+			t,
+			[]time.Duration{time.Second, 10 * time.Second, time.Minute, 10 * time.Minute},
+			cfg.Transports["amqp"].RetryStrategy.Schedule,
+		)
+		require.NotNil(t, cfg.Transports["redis"].RetryStrategy)
+		assert.Equal(t, "my_strategy", cfg.Transports["redis"].RetryStrategy.Service)
+	})
 }
 
 func TestYAMLParser_Integration(t *testing.T) {
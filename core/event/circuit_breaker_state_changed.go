@@ -0,0 +1,14 @@
+package event
+
+import (
+	"context"
+
+	"github.com/gerfey/messenger/core/circuitbreaker"
+)
+
+type CircuitBreakerStateChangedEvent struct {
+	Ctx  context.Context
+	Name string
+	From circuitbreaker.State
+	To   circuitbreaker.State
+}
@@ -1,9 +1,33 @@
 package event
 
-import "github.com/gerfey/messenger/api"
+import (
+	"sync/atomic"
+
+	"github.com/gerfey/messenger/api"
+)
 
 type SendFailedMessageEvent struct {
 	Envelope      api.Envelope
 	Error         error
 	TransportName string
+	handedOff     *atomic.Bool
+}
+
+func NewSendFailedMessageEvent(env api.Envelope, err error, transportName string) SendFailedMessageEvent {
+	return SendFailedMessageEvent{
+		Envelope:      env,
+		Error:         err,
+		TransportName: transportName,
+		handedOff:     &atomic.Bool{},
+	}
+}
+
+func (e SendFailedMessageEvent) MarkHandedOff() {
+	if e.handedOff != nil {
+		e.handedOff.Store(true)
+	}
+}
+
+func (e SendFailedMessageEvent) HandedOff() bool {
+	return e.handedOff != nil && e.handedOff.Load()
 }
@@ -0,0 +1,11 @@
+package event
+
+import (
+	"context"
+)
+
+type TransportConnectionLostEvent struct {
+	Ctx           context.Context
+	TransportName string
+	Error         error
+}
@@ -0,0 +1,13 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+type TransportConnectionRestoredEvent struct {
+	Ctx           context.Context
+	TransportName string
+	Attempts      uint
+	Downtime      time.Duration
+}
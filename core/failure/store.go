@@ -0,0 +1,197 @@
+package failure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/stamps"
+)
+
+const transportIDSeparator = ":"
+
+type Store struct {
+	failureTransports []api.ListableTransport
+	transports        map[string]api.Transport
+}
+
+func NewStore(failureTransports []api.ListableTransport, transports map[string]api.Transport) api.FailedMessageStore {
+	return &Store{
+		failureTransports: failureTransports,
+		transports:        transports,
+	}
+}
+
+func (s *Store) List(ctx context.Context, limit int) ([]api.Envelope, error) {
+	result := make([]api.Envelope, 0)
+
+	for _, ft := range s.failureTransports {
+		remaining := 0
+		if limit > 0 {
+			remaining = limit - len(result)
+			if remaining <= 0 {
+				break
+			}
+		}
+
+		envs, err := ft.List(ctx, remaining)
+		if err != nil {
+			return nil, fmt.Errorf("list failed messages from '%s': %w", ft.Name(), err)
+		}
+
+		for _, env := range envs {
+			result = append(result, withFailureTransport(env, ft.Name()))
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Store) Find(ctx context.Context, id string) (api.Envelope, error) {
+	_, env, err := s.find(ctx, id)
+
+	return env, err
+}
+
+func (s *Store) Retry(ctx context.Context, ids ...string) error {
+	var errs []error
+
+	for _, id := range ids {
+		if err := s.retry(ctx, id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Store) Remove(ctx context.Context, ids ...string) error {
+	var errs []error
+
+	for _, id := range ids {
+		ft, env, err := s.find(ctx, id)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if removeErr := s.remove(ctx, ft, env); removeErr != nil {
+			errs = append(errs, removeErr)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Store) retry(ctx context.Context, id string) error {
+	ft, env, err := s.find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	failedStamp, ok := envelope.LastStampOf[stamps.SentToFailureTransportStamp](env)
+	if !ok {
+		return fmt.Errorf("failed message '%s' has no original transport", id)
+	}
+
+	target, ok := s.transports[failedStamp.OriginalTransport]
+	if !ok {
+		return fmt.Errorf("original transport '%s' of failed message '%s' not found", failedStamp.OriginalTransport, id)
+	}
+
+	if sendErr := target.Send(ctx, redeliverable(env)); sendErr != nil {
+		return fmt.Errorf("retry failed message '%s' to '%s': %w", id, target.Name(), sendErr)
+	}
+
+	return s.remove(ctx, ft, env)
+}
+
+func (s *Store) remove(ctx context.Context, ft api.ListableTransport, env api.Envelope) error {
+	idStamp, _ := envelope.LastStampOf[stamps.TransportMessageIDStamp](env)
+
+	if err := ft.Remove(ctx, idStamp.ID); err != nil {
+		return fmt.Errorf("remove failed message '%s' from '%s': %w", idStamp.ID, ft.Name(), err)
+	}
+
+	return nil
+}
+
+func (s *Store) find(ctx context.Context, id string) (api.ListableTransport, api.Envelope, error) {
+	if name, transportID, ok := strings.Cut(id, transportIDSeparator); ok {
+		for _, ft := range s.failureTransports {
+			if ft.Name() != name {
+				continue
+			}
+
+			env, err := ft.Find(ctx, transportID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("find failed message '%s' in '%s': %w", transportID, name, err)
+			}
+
+			return ft, withFailureTransport(env, name), nil
+		}
+	}
+
+	var (
+		found    api.Envelope
+		foundIn  api.ListableTransport
+		notFound []error
+	)
+
+	for _, ft := range s.failureTransports {
+		env, err := ft.Find(ctx, id)
+		if err != nil {
+			notFound = append(notFound, fmt.Errorf("find failed message '%s' in '%s': %w", id, ft.Name(), err))
+
+			continue
+		}
+
+		if foundIn != nil {
+			return nil, nil, fmt.Errorf(
+				"failed message '%s' is ambiguous: found in '%s' and '%s', use <transport>%s<id>",
+				id, foundIn.Name(), ft.Name(), transportIDSeparator,
+			)
+		}
+
+		found, foundIn = env, ft
+	}
+
+	if foundIn == nil {
+		if len(notFound) == 0 {
+			return nil, nil, fmt.Errorf("failed message '%s' not found: no failure transports configured", id)
+		}
+
+		return nil, nil, errors.Join(notFound...)
+	}
+
+	return foundIn, withFailureTransport(found, foundIn.Name()), nil
+}
+
+func withFailureTransport(env api.Envelope, name string) api.Envelope {
+	idStamp, _ := envelope.LastStampOf[stamps.TransportMessageIDStamp](env)
+
+	return env.WithStamp(stamps.TransportMessageIDStamp{ID: idStamp.ID, Transport: name})
+}
+
+func redeliverable(env api.Envelope) api.Envelope {
+	result := envelope.NewEnvelope(env.Message())
+
+	for _, stamp := range env.Stamps() {
+		switch stamp.(type) {
+		case stamps.ReceivedStamp,
+			stamps.RedeliveryStamp,
+			stamps.DelayStamp,
+			stamps.SentToFailureTransportStamp,
+			stamps.TransportMessageIDStamp:
+			continue
+		}
+
+		result = result.WithStamp(stamp)
+	}
+
+	return result
+}
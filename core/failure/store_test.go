@@ -0,0 +1,213 @@
+package failure_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/failure"
+	"github.com/gerfey/messenger/core/stamps"
+	"github.com/gerfey/messenger/tests/helpers"
+	"github.com/gerfey/messenger/transport/inmemory"
+)
+
+func newFailedEnvelope(content string) api.Envelope {
+	return envelope.NewEnvelope(&helpers.TestMessage{Content: content}).
+		WithStamp(stamps.ReceivedStamp{Transport: "orders"}).
+		WithStamp(stamps.RedeliveryStamp{RetryCount: 3}).
+		WithStamp(stamps.ErrorDetailsStamp{ErrorMessage: "boom", FailedAt: time.Now(), RetryCount: 3}).
+		WithStamp(stamps.SentToFailureTransportStamp{OriginalTransport: "orders"}).
+		WithStamp(stamps.DelayStamp{})
+}
+
+func setupStore(t *testing.T) (api.FailedMessageStore, api.ListableTransport, api.ListableTransport) {
+	t.Helper()
+
+	failed := inmemory.NewTransport("failed").(api.ListableTransport)
+	orders := inmemory.NewTransport("orders").(api.ListableTransport)
+
+	store := failure.NewStore([]api.ListableTransport{failed}, map[string]api.Transport{
+		"failed": failed,
+		"orders": orders,
+	})
+
+	return store, failed, orders
+}
+
+func TestStore_List(t *testing.T) {
+	t.Run("lists failed messages with transport ids", func(t *testing.T) {
+		store, failed, _ := setupStore(t)
+
+		require.NoError(t, failed.Send(t.Context(), newFailedEnvelope("first")))
+		require.NoError(t, failed.Send(t.Context(), newFailedEnvelope("second")))
+
+		envs, err := store.List(t.Context(), 0)
+		require.NoError(t, err)
+		require.Len(t, envs, 2)
+
+		for _, env := range envs {
+			assert.True(t, envelope.HasStampOf[stamps.TransportMessageIDStamp](env))
+		}
+	})
+
+	t.Run("respects limit", func(t *testing.T) {
+		store, failed, _ := setupStore(t)
+
+		require.NoError(t, failed.Send(t.Context(), newFailedEnvelope("first")))
+		require.NoError(t, failed.Send(t.Context(), newFailedEnvelope("second")))
+
+		envs, err := store.List(t.Context(), 1)
+		require.NoError(t, err)
+		assert.Len(t, envs, 1)
+	})
+}
+
+func TestStore_Find(t *testing.T) {
+	t.Run("returns error for unknown id", func(t *testing.T) {
+		store, _, _ := setupStore(t)
+
+		_, err := store.Find(t.Context(), "unknown")
+		require.Error(t, err)
+	})
+
+	t.Run("returns failure transport name with id", func(t *testing.T) {
+		store, failed, _ := setupStore(t)
+
+		require.NoError(t, failed.Send(t.Context(), newFailedEnvelope("first")))
+
+		env, err := store.Find(t.Context(), "1")
+		require.NoError(t, err)
+
+		idStamp, ok := envelope.LastStampOf[stamps.TransportMessageIDStamp](env)
+		require.True(t, ok)
+		assert.Equal(t, "1", idStamp.ID)
+		assert.Equal(t, "failed", idStamp.Transport)
+	})
+}
+
+func TestStore_MultipleFailureTransports(t *testing.T) {
+	setup := func(t *testing.T) (api.FailedMessageStore, api.ListableTransport, api.ListableTransport) {
+		t.Helper()
+
+		failedPayments := inmemory.NewTransport("failed_payments").(api.ListableTransport)
+		failedNotifications := inmemory.NewTransport("failed_notifications").(api.ListableTransport)
+		orders := inmemory.NewTransport("orders").(api.ListableTransport)
+
+		store := failure.NewStore(
+			[]api.ListableTransport{failedPayments, failedNotifications},
+			map[string]api.Transport{"orders": orders},
+		)
+
+		return store, failedPayments, failedNotifications
+	}
+
+	t.Run("lists messages from every failure transport", func(t *testing.T) {
+		store, payments, notifications := setup(t)
+
+		require.NoError(t, payments.Send(t.Context(), newFailedEnvelope("payment")))
+		require.NoError(t, notifications.Send(t.Context(), newFailedEnvelope("notification")))
+
+		envs, err := store.List(t.Context(), 0)
+		require.NoError(t, err)
+		require.Len(t, envs, 2)
+
+		first, _ := envelope.LastStampOf[stamps.TransportMessageIDStamp](envs[0])
+		second, _ := envelope.LastStampOf[stamps.TransportMessageIDStamp](envs[1])
+		assert.Equal(t, "failed_payments", first.Transport)
+		assert.Equal(t, "failed_notifications", second.Transport)
+
+		limited, err := store.List(t.Context(), 1)
+		require.NoError(t, err)
+		assert.Len(t, limited, 1)
+	})
+
+	t.Run("rejects ambiguous ids and accepts qualified ids", func(t *testing.T) {
+		store, payments, notifications := setup(t)
+
+		require.NoError(t, payments.Send(t.Context(), newFailedEnvelope("payment")))
+		require.NoError(t, notifications.Send(t.Context(), newFailedEnvelope("notification")))
+
+		_, err := store.Find(t.Context(), "1")
+		require.ErrorContains(t, err, "ambiguous")
+
+		env, err := store.Find(t.Context(), "failed_notifications:1")
+		require.NoError(t, err)
+		assert.Equal(t, "notification", env.Message().(*helpers.TestMessage).Content)
+
+		require.NoError(t, store.Remove(t.Context(), "failed_payments:1"))
+
+		remaining, err := payments.List(t.Context(), 0)
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+
+		require.NoError(t, store.Remove(t.Context(), "1"))
+	})
+}
+
+func TestStore_Retry(t *testing.T) {
+	t.Run("sends message back to original transport and removes it", func(t *testing.T) {
+		store, failed, orders := setupStore(t)
+
+		require.NoError(t, failed.Send(t.Context(), newFailedEnvelope("retry me")))
+
+		envs, err := store.List(t.Context(), 0)
+		require.NoError(t, err)
+		require.Len(t, envs, 1)
+
+		idStamp, _ := envelope.LastStampOf[stamps.TransportMessageIDStamp](envs[0])
+
+		require.NoError(t, store.Retry(t.Context(), idStamp.ID))
+
+		remaining, err := failed.List(t.Context(), 0)
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+
+		retried, err := orders.List(t.Context(), 0)
+		require.NoError(t, err)
+		require.Len(t, retried, 1)
+
+		assert.False(t, envelope.HasStampOf[stamps.RedeliveryStamp](retried[0]))
+		assert.False(t, envelope.HasStampOf[stamps.ReceivedStamp](retried[0]))
+		assert.False(t, envelope.HasStampOf[stamps.SentToFailureTransportStamp](retried[0]))
+		assert.Len(t, envelope.StampsOf[stamps.ErrorDetailsStamp](retried[0]), 1)
+	})
+
+	t.Run("fails when original transport is unknown", func(t *testing.T) {
+		failed := inmemory.NewTransport("failed").(api.ListableTransport)
+		store := failure.NewStore([]api.ListableTransport{failed}, map[string]api.Transport{})
+
+		require.NoError(t, failed.Send(t.Context(), newFailedEnvelope("lost")))
+
+		err := store.Retry(t.Context(), "1")
+		require.Error(t, err)
+
+		remaining, err := failed.List(t.Context(), 0)
+		require.NoError(t, err)
+		assert.Len(t, remaining, 1)
+	})
+}
+
+func TestStore_Remove(t *testing.T) {
+	t.Run("removes messages", func(t *testing.T) {
+		store, failed, _ := setupStore(t)
+
+		require.NoError(t, failed.Send(t.Context(), newFailedEnvelope("first")))
+		require.NoError(t, failed.Send(t.Context(), newFailedEnvelope("second")))
+
+		require.NoError(t, store.Remove(t.Context(), "1", "2"))
+
+		remaining, err := failed.List(t.Context(), 0)
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+	})
+
+	t.Run("returns error for unknown id", func(t *testing.T) {
+		store, _, _ := setupStore(t)
+
+		require.Error(t, store.Remove(t.Context(), "404"))
+	})
+}
@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"reflect"
 	"runtime"
+	"time"
 
 	"github.com/gerfey/messenger/api"
 )
 
 const (
-	expectedHandlerParams = 3
-	messageTypeParamIndex = 2
+	expectedHandlerParams  = 3
+	messageTypeParamIndex  = 2
+	expectedHandlerResults = 2
+	defaultBatchSize       = 100
+	defaultBatchTimeout    = time.Second
 )
 
 type Locator struct {
@@ -49,7 +53,7 @@ func (r *Locator) Register(handler any) error {
 	}
 
 	errorInterface := reflect.TypeOf((*error)(nil)).Elem()
-	if method.Type.NumOut() != 1 && method.Type.NumOut() != 2 {
+	if method.Type.NumOut() != 1 && method.Type.NumOut() != expectedHandlerResults {
 		return fmt.Errorf(
 			"handler %T: Handle method must return error or (result, error), got %d return values",
 			handler,
@@ -73,12 +77,24 @@ func (r *Locator) Register(handler any) error {
 		}
 	}
 
-	r.handlers[msgType] = append(r.handlers[msgType], api.HandlerFunc{
+	handlerFunc := api.HandlerFunc{
 		Fn:         v.MethodByName("Handle"),
 		InputType:  msgType,
 		HandlerStr: runtimeFuncName(handler),
 		BusName:    busName,
-	})
+	}
+
+	if method.Type.NumOut() == expectedHandlerResults {
+		handlerFunc.ResultType = method.Type.Out(0)
+	}
+
+	if msgType.Kind() == reflect.Slice {
+		handlerFunc.InputType = msgType.Elem()
+		handlerFunc.Batch = true
+		handlerFunc.BatchSize, handlerFunc.BatchTimeout = batchWindow(handler)
+	}
+
+	r.handlers[handlerFunc.InputType] = append(r.handlers[handlerFunc.InputType], handlerFunc)
 
 	return nil
 }
@@ -122,6 +138,22 @@ func (r *Locator) ResolveMessageType(typeStr string) (reflect.Type, error) {
 	return nil, fmt.Errorf("message type %q not found in registry", typeStr)
 }
 
+func batchWindow(handler any) (int, time.Duration) {
+	size, timeout := defaultBatchSize, defaultBatchTimeout
+
+	if batchHandler, ok := handler.(api.BatchHandler); ok {
+		if batchHandler.BatchSize() > 0 {
+			size = batchHandler.BatchSize()
+		}
+
+		if batchHandler.BatchTimeout() > 0 {
+			timeout = batchHandler.BatchTimeout()
+		}
+	}
+
+	return size, timeout
+}
+
 func runtimeFuncName(i any) string {
 	v := reflect.ValueOf(i)
 	var fn reflect.Value
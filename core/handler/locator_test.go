@@ -3,6 +3,7 @@ package handler_test
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -206,6 +207,32 @@ func TestLocator_Get(t *testing.T) {
 	})
 }
 
+func TestLocator_Register_BatchHandler(t *testing.T) {
+	t.Run("registers batch handler by element type with default window", func(t *testing.T) {
+		locator := handler.NewHandlerLocator()
+		require.NoError(t, locator.Register(&helpers.TestBatchHandler{}))
+
+		handlers := locator.Get(&helpers.TestMessage{})
+
+		require.Len(t, handlers, 1)
+		assert.True(t, handlers[0].Batch)
+		assert.Equal(t, reflect.TypeOf(&helpers.TestMessage{}), handlers[0].InputType)
+		assert.Equal(t, 100, handlers[0].BatchSize)
+		assert.Equal(t, time.Second, handlers[0].BatchTimeout)
+	})
+
+	t.Run("uses batch window of the handler", func(t *testing.T) {
+		locator := handler.NewHandlerLocator()
+		require.NoError(t, locator.Register(&helpers.TestBatchHandler{Size: 500, Timeout: 5 * time.Second}))
+
+		handlers := locator.Get(&helpers.TestMessage{})
+
+		require.Len(t, handlers, 1)
+		assert.Equal(t, 500, handlers[0].BatchSize)
+		assert.Equal(t, 5*time.Second, handlers[0].BatchTimeout)
+	})
+}
+
 func TestLocator_GetAll(t *testing.T) {
 	t.Run("get all handlers from empty locator", func(t *testing.T) {
 		locator := handler.NewHandlerLocator()
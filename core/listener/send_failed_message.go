@@ -2,9 +2,12 @@ package listener
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"reflect"
 	"time"
 
+	"github.com/gerfey/messenger"
 	"github.com/gerfey/messenger/api"
 	"github.com/gerfey/messenger/core/envelope"
 	"github.com/gerfey/messenger/core/event"
@@ -13,10 +16,11 @@ import (
 )
 
 type SendFailedMessageForRetryListener struct {
-	logger           *slog.Logger
-	transport        api.RetryableTransport
-	failureTransport api.Transport
-	retryStrategy    retry.Strategy
+	logger            *slog.Logger
+	transport         api.RetryableTransport
+	failureTransport  api.Transport
+	retryStrategy     retry.Strategy
+	messageStrategies map[reflect.Type]retry.Strategy
 }
 
 func NewSendFailedMessageForRetryListener(
@@ -33,6 +37,14 @@ func NewSendFailedMessageForRetryListener(
 	}
 }
 
+func (l *SendFailedMessageForRetryListener) WithMessageStrategies(
+	strategies map[reflect.Type]retry.Strategy,
+) *SendFailedMessageForRetryListener {
+	l.messageStrategies = strategies
+
+	return l
+}
+
 func (l *SendFailedMessageForRetryListener) Handle(ctx context.Context, evt event.SendFailedMessageEvent) {
 	env := evt.Envelope
 
@@ -41,7 +53,7 @@ func (l *SendFailedMessageForRetryListener) Handle(ctx context.Context, evt even
 		return
 	}
 
-	if receivedStamp.Transport != evt.TransportName {
+	if receivedStamp.Transport != evt.TransportName || evt.TransportName != l.transport.Name() {
 		return
 	}
 
@@ -58,24 +70,109 @@ func (l *SendFailedMessageForRetryListener) Handle(ctx context.Context, evt even
 	}
 	env = env.WithStamp(errorStamp)
 
-	delay, shouldRetry := l.retryStrategy.ShouldRetry(nextRetry)
-	if !shouldRetry {
-		if l.failureTransport != nil {
-			err := l.failureTransport.Send(ctx, env)
-			if err != nil {
-				l.logger.ErrorContext(ctx, "failed to send message to failure transport", "error", err)
-			}
+	var unrecoverable *messenger.UnrecoverableError
+	if errors.As(evt.Error, &unrecoverable) {
+		l.sendToFailureTransport(ctx, evt, env)
+
+		return
+	}
+
+	strategy := l.strategyFor(env)
+	delay, shouldRetry := retryDelay(strategy, env, nextRetry)
+
+	var recoverable *messenger.RecoverableError
+	if errors.As(evt.Error, &recoverable) {
+		if recoverable.Delay > 0 {
+			delay = recoverable.Delay
+		} else if !shouldRetry {
+			delay = exhaustedDelay(strategy, env)
 		}
 
+		shouldRetry = true
+	}
+
+	if !shouldRetry {
+		l.sendToFailureTransport(ctx, evt, env)
+
+		return
+	}
+
+	newEnv := env.
+		WithStamp(stamps.RedeliveryStamp{RetryCount: nextRetry}).
+		WithStamp(stamps.NewDelayStamp(delay))
+
+	err := l.transport.Retry(ctx, newEnv)
+	if err != nil {
+		l.logger.ErrorContext(ctx, "retry dispatch failed", "error", err)
+
 		return
 	}
 
-	newEnv := env.WithStamp(stamps.RedeliveryStamp{RetryCount: nextRetry})
+	evt.MarkHandedOff()
+}
+
+// retryDelay hands strategies like decorrelated jitter the delay of the previous retry.
+func retryDelay(strategy retry.Strategy, env api.Envelope, retryCount uint) (time.Duration, bool) {
+	decorrelated, ok := strategy.(api.PreviousDelayRetryStrategy)
+	if !ok {
+		return strategy.ShouldRetry(retryCount)
+	}
 
-	time.AfterFunc(delay, func() {
-		err := l.transport.Retry(ctx, newEnv)
-		if err != nil {
-			l.logger.ErrorContext(ctx, "retry dispatch failed", "error", err)
+	var previous time.Duration
+	if _, retried := envelope.LastStampOf[stamps.RedeliveryStamp](env); retried {
+		if delayStamp, hasDelay := envelope.LastStampOf[stamps.DelayStamp](env); hasDelay {
+			previous = delayStamp.Duration()
 		}
-	})
+	}
+
+	return decorrelated.ShouldRetryAfter(retryCount, previous)
+}
+
+// exhaustedDelay keeps retrying a recoverable error past the strategy's limit with the
+// previous delay, or the strategy's first delay when there is none.
+func exhaustedDelay(strategy retry.Strategy, env api.Envelope) time.Duration {
+	if delayStamp, ok := envelope.LastStampOf[stamps.DelayStamp](env); ok && delayStamp.Milliseconds > 0 {
+		return delayStamp.Duration()
+	}
+
+	delay, _ := strategy.ShouldRetry(0)
+
+	return delay
+}
+
+func (l *SendFailedMessageForRetryListener) strategyFor(env api.Envelope) retry.Strategy {
+	if strategy, ok := l.messageStrategies[reflect.TypeOf(env.Message())]; ok {
+		return strategy
+	}
+
+	if provider, ok := env.Message().(api.RetryPolicyProvider); ok {
+		if strategy := provider.RetryStrategy(); strategy != nil {
+			return strategy
+		}
+	}
+
+	return l.retryStrategy
+}
+
+func (l *SendFailedMessageForRetryListener) sendToFailureTransport(
+	ctx context.Context,
+	evt event.SendFailedMessageEvent,
+	env api.Envelope,
+) {
+	if l.failureTransport == nil {
+		return
+	}
+
+	failedEnv := env.
+		WithStamp(stamps.SentToFailureTransportStamp{OriginalTransport: evt.TransportName}).
+		WithStamp(stamps.DelayStamp{})
+
+	err := l.failureTransport.Send(ctx, failedEnv)
+	if err != nil {
+		l.logger.ErrorContext(ctx, "failed to send message to failure transport", "error", err)
+
+		return
+	}
+
+	evt.MarkHandedOff()
 }
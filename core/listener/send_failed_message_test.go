@@ -1,8 +1,11 @@
 package listener_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"reflect"
 	"testing"
 	"time"
 
@@ -10,6 +13,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/gerfey/messenger"
+	"github.com/gerfey/messenger/api"
 	"github.com/gerfey/messenger/core/envelope"
 	"github.com/gerfey/messenger/core/event"
 	"github.com/gerfey/messenger/core/listener"
@@ -19,12 +24,21 @@ import (
 	"github.com/gerfey/messenger/tests/mocks"
 )
 
+type retryPolicyMessage struct {
+	strategy retry.Strategy
+}
+
+func (m *retryPolicyMessage) RetryStrategy() retry.Strategy {
+	return m.strategy
+}
+
 func TestNewSendFailedMessageForRetryListener(t *testing.T) {
 	t.Run("creates listener with all dependencies", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
 		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
 		mockFailureTransport := mocks.NewMockTransport(ctrl)
 		mockStrategy := mocks.NewMockStrategy(ctrl)
 		logger, _ := helpers.NewFakeLogger()
@@ -44,6 +58,7 @@ func TestNewSendFailedMessageForRetryListener(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
 		mockStrategy := mocks.NewMockStrategy(ctrl)
 		logger, _ := helpers.NewFakeLogger()
 
@@ -64,6 +79,7 @@ func TestSendFailedMessageForRetryListener_Handle(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
 		mockStrategy := mocks.NewMockStrategy(ctrl)
 		logger, fakeLogger := helpers.NewFakeLogger()
 
@@ -101,6 +117,7 @@ func TestSendFailedMessageForRetryListener_Handle(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
 		mockFailureTransport := mocks.NewMockTransport(ctrl)
 		mockStrategy := mocks.NewMockStrategy(ctrl)
 		logger, fakeLogger := helpers.NewFakeLogger()
@@ -137,6 +154,7 @@ func TestSendFailedMessageForRetryListener_Handle(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
 		mockFailureTransport := mocks.NewMockTransport(ctrl)
 		mockStrategy := mocks.NewMockStrategy(ctrl)
 		logger, fakeLogger := helpers.NewFakeLogger()
@@ -174,6 +192,7 @@ func TestSendFailedMessageForRetryListener_Handle(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
 		mockStrategy := mocks.NewMockStrategy(ctrl)
 		logger, _ := helpers.NewFakeLogger()
 
@@ -208,11 +227,282 @@ func TestSendFailedMessageForRetryListener_Handle(t *testing.T) {
 		time.Sleep(10 * time.Millisecond)
 	})
 
+	t.Run("hands envelope back to transport immediately with delay", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
+		mockStrategy := mocks.NewMockStrategy(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+
+		l := listener.NewSendFailedMessageForRetryListener(
+			logger,
+			mockTransport,
+			nil,
+			mockStrategy,
+		)
+
+		msg := &helpers.TestMessage{ID: "123", Content: "test"}
+		env := envelope.NewEnvelope(msg).WithStamp(stamps.ReceivedStamp{
+			Transport: "test-transport",
+		})
+
+		evt := event.SendFailedMessageEvent{
+			Envelope:      env,
+			TransportName: "test-transport",
+			Error:         errors.New("send failed"),
+		}
+
+		mockStrategy.EXPECT().ShouldRetry(uint(0)).Return(time.Minute, true)
+
+		var retried api.Envelope
+		mockTransport.EXPECT().Retry(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, e api.Envelope) error {
+				retried = e
+
+				return nil
+			},
+		)
+
+		l.Handle(t.Context(), evt)
+
+		require.NotNil(t, retried)
+
+		delayStamp, ok := envelope.LastStampOf[stamps.DelayStamp](retried)
+		require.True(t, ok)
+		assert.Equal(t, time.Minute, delayStamp.Duration())
+
+		redeliveryStamp, ok := envelope.LastStampOf[stamps.RedeliveryStamp](retried)
+		require.True(t, ok)
+		assert.Equal(t, uint(0), redeliveryStamp.RetryCount)
+	})
+
+	t.Run("resets delay when sending to failure transport", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
+		mockFailureTransport := mocks.NewMockTransport(ctrl)
+		mockStrategy := mocks.NewMockStrategy(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+
+		l := listener.NewSendFailedMessageForRetryListener(
+			logger,
+			mockTransport,
+			mockFailureTransport,
+			mockStrategy,
+		)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{ID: "123"}).
+			WithStamp(stamps.DelayStamp{Milliseconds: 500}).
+			WithStamp(stamps.ReceivedStamp{Transport: "test-transport"})
+
+		evt := event.SendFailedMessageEvent{
+			Envelope:      env,
+			TransportName: "test-transport",
+			Error:         errors.New("send failed"),
+		}
+
+		mockStrategy.EXPECT().ShouldRetry(uint(0)).Return(time.Duration(0), false)
+
+		var failed api.Envelope
+		mockFailureTransport.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, e api.Envelope) error {
+				failed = e
+
+				return nil
+			},
+		)
+
+		l.Handle(t.Context(), evt)
+
+		require.NotNil(t, failed)
+
+		delayStamp, ok := envelope.LastStampOf[stamps.DelayStamp](failed)
+		require.True(t, ok)
+		assert.Zero(t, delayStamp.Milliseconds)
+	})
+
+	t.Run("sends unrecoverable error straight to failure transport", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
+		mockFailureTransport := mocks.NewMockTransport(ctrl)
+		mockStrategy := mocks.NewMockStrategy(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+
+		l := listener.NewSendFailedMessageForRetryListener(
+			logger,
+			mockTransport,
+			mockFailureTransport,
+			mockStrategy,
+		)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{ID: "123"}).
+			WithStamp(stamps.ReceivedStamp{Transport: "test-transport"})
+
+		evt := event.SendFailedMessageEvent{
+			Envelope:      env,
+			TransportName: "test-transport",
+			Error:         fmt.Errorf("handle: %w", messenger.Unrecoverable(errors.New("invalid payload"))),
+		}
+
+		mockStrategy.EXPECT().ShouldRetry(gomock.Any()).Times(0)
+		mockTransport.EXPECT().Retry(gomock.Any(), gomock.Any()).Times(0)
+		mockFailureTransport.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+		l.Handle(t.Context(), evt)
+	})
+
+	t.Run("retries recoverable error with handler delay beyond strategy limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
+		mockFailureTransport := mocks.NewMockTransport(ctrl)
+		mockStrategy := mocks.NewMockStrategy(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+
+		l := listener.NewSendFailedMessageForRetryListener(
+			logger,
+			mockTransport,
+			mockFailureTransport,
+			mockStrategy,
+		)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{ID: "123"}).
+			WithStamp(stamps.RedeliveryStamp{RetryCount: 10}).
+			WithStamp(stamps.ReceivedStamp{Transport: "test-transport"})
+
+		evt := event.SendFailedMessageEvent{
+			Envelope:      env,
+			TransportName: "test-transport",
+			Error:         messenger.RecoverableAfter(errors.New("rate limited"), 30*time.Second),
+		}
+
+		mockStrategy.EXPECT().ShouldRetry(uint(11)).Return(time.Duration(0), false)
+		mockFailureTransport.EXPECT().Send(gomock.Any(), gomock.Any()).Times(0)
+
+		var retried api.Envelope
+		mockTransport.EXPECT().Retry(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, e api.Envelope) error {
+				retried = e
+
+				return nil
+			},
+		)
+
+		l.Handle(t.Context(), evt)
+
+		require.NotNil(t, retried)
+
+		delayStamp, ok := envelope.LastStampOf[stamps.DelayStamp](retried)
+		require.True(t, ok)
+		assert.Equal(t, 30*time.Second, delayStamp.Duration())
+	})
+
+	t.Run("retries recoverable error without delay once strategy is exhausted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
+		mockFailureTransport := mocks.NewMockTransport(ctrl)
+		mockStrategy := mocks.NewMockStrategy(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+
+		l := listener.NewSendFailedMessageForRetryListener(
+			logger,
+			mockTransport,
+			mockFailureTransport,
+			mockStrategy,
+		)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{ID: "123"}).
+			WithStamp(stamps.ReceivedStamp{Transport: "test-transport"}).
+			WithStamp(stamps.RedeliveryStamp{RetryCount: 2}).
+			WithStamp(stamps.NewDelayStamp(time.Second))
+
+		evt := event.NewSendFailedMessageEvent(env, messenger.Recoverable(errors.New("temporary")), "test-transport")
+
+		mockStrategy.EXPECT().ShouldRetry(uint(3)).Return(time.Duration(0), false)
+		mockFailureTransport.EXPECT().Send(gomock.Any(), gomock.Any()).Times(0)
+
+		var retried api.Envelope
+		mockTransport.EXPECT().Retry(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, e api.Envelope) error {
+				retried = e
+
+				return nil
+			},
+		)
+
+		l.Handle(t.Context(), evt)
+
+		assert.True(t, evt.HandedOff())
+		require.NotNil(t, retried)
+
+		delayStamp, ok := envelope.LastStampOf[stamps.DelayStamp](retried)
+		require.True(t, ok)
+		assert.Equal(t, time.Second, delayStamp.Duration())
+	})
+
+	t.Run("retries recoverable error with strategy delay when no delay given", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
+		mockStrategy := mocks.NewMockStrategy(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+
+		l := listener.NewSendFailedMessageForRetryListener(
+			logger,
+			mockTransport,
+			nil,
+			mockStrategy,
+		)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{ID: "123"}).
+			WithStamp(stamps.ReceivedStamp{Transport: "test-transport"})
+
+		evt := event.SendFailedMessageEvent{
+			Envelope:      env,
+			TransportName: "test-transport",
+			Error:         messenger.Recoverable(errors.New("temporary")),
+		}
+
+		mockStrategy.EXPECT().ShouldRetry(uint(0)).Return(time.Second, true)
+
+		var retried api.Envelope
+		mockTransport.EXPECT().Retry(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, e api.Envelope) error {
+				retried = e
+
+				return nil
+			},
+		)
+
+		l.Handle(t.Context(), evt)
+
+		require.NotNil(t, retried)
+
+		delayStamp, ok := envelope.LastStampOf[stamps.DelayStamp](retried)
+		require.True(t, ok)
+		assert.Equal(t, time.Second, delayStamp.Duration())
+	})
+
 	t.Run("ignores event without ReceivedStamp", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
 		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
 		mockStrategy := mocks.NewMockStrategy(ctrl)
 		logger, _ := helpers.NewFakeLogger()
 
@@ -235,11 +525,39 @@ func TestSendFailedMessageForRetryListener_Handle(t *testing.T) {
 		l.Handle(t.Context(), evt)
 	})
 
+	t.Run("ignores failures of another transport", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
+		mockStrategy := mocks.NewMockStrategy(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+
+		l := listener.NewSendFailedMessageForRetryListener(
+			logger,
+			mockTransport,
+			nil,
+			mockStrategy,
+		)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{ID: "123"}).WithStamp(stamps.ReceivedStamp{
+			Transport: "other-transport",
+		})
+
+		l.Handle(t.Context(), event.SendFailedMessageEvent{
+			Envelope:      env,
+			TransportName: "other-transport",
+			Error:         errors.New("send failed"),
+		})
+	})
+
 	t.Run("ignores event with mismatched transport name", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
 		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
 		mockStrategy := mocks.NewMockStrategy(ctrl)
 		logger, _ := helpers.NewFakeLogger()
 
@@ -269,6 +587,7 @@ func TestSendFailedMessageForRetryListener_Handle(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
 		mockStrategy := mocks.NewMockStrategy(ctrl)
 		logger, _ := helpers.NewFakeLogger()
 
@@ -302,6 +621,7 @@ func TestSendFailedMessageForRetryListener_Integration(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
 		logger, fakeLogger := helpers.NewFakeLogger()
 
 		strategy := retry.NewMultiplierRetryStrategy(3, 10*time.Millisecond, 2.0, 100*time.Millisecond)
@@ -333,3 +653,257 @@ func TestSendFailedMessageForRetryListener_Integration(t *testing.T) {
 		assert.False(t, fakeLogger.HasMessage(slog.LevelError, "retry dispatch failed"))
 	})
 }
+
+func TestSendFailedMessageForRetryListener_RecoverablePastMaxRetries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransport := mocks.NewMockRetryableTransport(ctrl)
+	mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
+	mockFailureTransport := mocks.NewMockTransport(ctrl)
+	mockFailureTransport.EXPECT().Send(gomock.Any(), gomock.Any()).Times(0)
+	logger, _ := helpers.NewFakeLogger()
+
+	l := listener.NewSendFailedMessageForRetryListener(
+		logger,
+		mockTransport,
+		mockFailureTransport,
+		retry.NewFixedRetryStrategy(2, 50*time.Millisecond),
+	)
+
+	env := envelope.NewEnvelope(&helpers.TestMessage{ID: "123"}).
+		WithStamp(stamps.ReceivedStamp{Transport: "test-transport"})
+
+	var delays []time.Duration
+	mockTransport.EXPECT().Retry(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, e api.Envelope) error {
+			delayStamp, _ := envelope.LastStampOf[stamps.DelayStamp](e)
+			delays = append(delays, delayStamp.Duration())
+			env = e
+
+			return nil
+		},
+	).Times(5)
+
+	for range 5 {
+		evt := event.NewSendFailedMessageEvent(env, messenger.Recoverable(errors.New("temporary")), "test-transport")
+		l.Handle(t.Context(), evt)
+		require.True(t, evt.HandedOff())
+	}
+
+	assert.Equal(t, []time.Duration{
+		50 * time.Millisecond,
+		50 * time.Millisecond,
+		50 * time.Millisecond,
+		50 * time.Millisecond,
+		50 * time.Millisecond,
+	}, delays)
+
+	redelivery, ok := envelope.LastStampOf[stamps.RedeliveryStamp](env)
+	require.True(t, ok)
+	assert.Equal(t, uint(4), redelivery.RetryCount)
+}
+
+func TestSendFailedMessageForRetryListener_DecorrelatedJitter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransport := mocks.NewMockRetryableTransport(ctrl)
+	mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
+	logger, _ := helpers.NewFakeLogger()
+
+	l := listener.NewSendFailedMessageForRetryListener(
+		logger,
+		mockTransport,
+		nil,
+		retry.NewExponentialRetryStrategy(10, 100*time.Millisecond, 2, 10*time.Second, retry.JitterDecorrelated),
+	)
+
+	env := envelope.NewEnvelope(&helpers.TestMessage{ID: "123"}).
+		WithStamp(stamps.ReceivedStamp{Transport: "test-transport"}).
+		WithStamp(stamps.RedeliveryStamp{RetryCount: 3}).
+		WithStamp(stamps.NewDelayStamp(2 * time.Second))
+
+	var retried api.Envelope
+	mockTransport.EXPECT().Retry(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, e api.Envelope) error {
+			retried = e
+
+			return nil
+		},
+	)
+
+	l.Handle(t.Context(), event.NewSendFailedMessageEvent(env, errors.New("temporary"), "test-transport"))
+
+	require.NotNil(t, retried)
+
+	delayStamp, ok := envelope.LastStampOf[stamps.DelayStamp](retried)
+	require.True(t, ok)
+	assert.GreaterOrEqual(t, delayStamp.Duration(), 100*time.Millisecond)
+	assert.LessOrEqual(t, delayStamp.Duration(), 6*time.Second)
+}
+
+func TestSendFailedMessageForRetryListener_MessageStrategies(t *testing.T) {
+	t.Run("uses configured strategy for message type", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
+		mockStrategy := mocks.NewMockStrategy(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+
+		l := listener.NewSendFailedMessageForRetryListener(logger, mockTransport, nil, mockStrategy).
+			WithMessageStrategies(map[reflect.Type]retry.Strategy{
+				reflect.TypeOf(&helpers.TestMessage{}): retry.NewFixedRetryStrategy(1, 5*time.Second),
+			})
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{ID: "123"}).
+			WithStamp(stamps.ReceivedStamp{Transport: "test-transport"})
+
+		mockStrategy.EXPECT().ShouldRetry(gomock.Any()).Times(0)
+
+		var retried api.Envelope
+		mockTransport.EXPECT().Retry(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, e api.Envelope) error {
+				retried = e
+
+				return nil
+			},
+		)
+
+		l.Handle(t.Context(), event.SendFailedMessageEvent{
+			Envelope:      env,
+			TransportName: "test-transport",
+			Error:         errors.New("send failed"),
+		})
+
+		require.NotNil(t, retried)
+
+		delayStamp, _ := envelope.LastStampOf[stamps.DelayStamp](retried)
+		assert.Equal(t, 5*time.Second, delayStamp.Duration())
+	})
+
+	t.Run("uses strategy provided by message", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
+		mockFailureTransport := mocks.NewMockTransport(ctrl)
+		mockStrategy := mocks.NewMockStrategy(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+
+		l := listener.NewSendFailedMessageForRetryListener(logger, mockTransport, mockFailureTransport, mockStrategy)
+
+		env := envelope.NewEnvelope(&retryPolicyMessage{strategy: retry.NewFixedRetryStrategy(0, time.Second)}).
+			WithStamp(stamps.ReceivedStamp{Transport: "test-transport"})
+
+		mockStrategy.EXPECT().ShouldRetry(gomock.Any()).Times(0)
+		mockTransport.EXPECT().Retry(gomock.Any(), gomock.Any()).Times(0)
+		mockFailureTransport.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+		l.Handle(t.Context(), event.SendFailedMessageEvent{
+			Envelope:      env,
+			TransportName: "test-transport",
+			Error:         errors.New("send failed"),
+		})
+	})
+
+	t.Run("falls back to transport strategy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
+		mockStrategy := mocks.NewMockStrategy(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+
+		l := listener.NewSendFailedMessageForRetryListener(logger, mockTransport, nil, mockStrategy).
+			WithMessageStrategies(map[reflect.Type]retry.Strategy{
+				reflect.TypeOf(&retryPolicyMessage{}): retry.NewFixedRetryStrategy(1, 5*time.Second),
+			})
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{ID: "123"}).
+			WithStamp(stamps.ReceivedStamp{Transport: "test-transport"})
+
+		mockStrategy.EXPECT().ShouldRetry(uint(0)).Return(time.Second, true)
+		mockTransport.EXPECT().Retry(gomock.Any(), gomock.Any()).Return(nil)
+
+		l.Handle(t.Context(), event.SendFailedMessageEvent{
+			Envelope:      env,
+			TransportName: "test-transport",
+			Error:         errors.New("send failed"),
+		})
+	})
+}
+
+func TestSendFailedMessageForRetryListener_HandedOff(t *testing.T) {
+	newEvent := func() event.SendFailedMessageEvent {
+		env := envelope.NewEnvelope(&helpers.TestMessage{ID: "123"}).
+			WithStamp(stamps.ReceivedStamp{Transport: "test-transport"})
+
+		return event.NewSendFailedMessageEvent(env, errors.New("send failed"), "test-transport")
+	}
+
+	t.Run("marks event handed off after successful retry", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
+		mockStrategy := mocks.NewMockStrategy(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+
+		l := listener.NewSendFailedMessageForRetryListener(logger, mockTransport, nil, mockStrategy)
+
+		mockStrategy.EXPECT().ShouldRetry(uint(0)).Return(time.Second, true)
+		mockTransport.EXPECT().Retry(gomock.Any(), gomock.Any()).Return(nil)
+
+		evt := newEvent()
+		l.Handle(t.Context(), evt)
+
+		assert.True(t, evt.HandedOff())
+	})
+
+	t.Run("does not mark event when retry fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
+		mockStrategy := mocks.NewMockStrategy(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+
+		l := listener.NewSendFailedMessageForRetryListener(logger, mockTransport, nil, mockStrategy)
+
+		mockStrategy.EXPECT().ShouldRetry(uint(0)).Return(time.Second, true)
+		mockTransport.EXPECT().Retry(gomock.Any(), gomock.Any()).Return(errors.New("broker down"))
+
+		evt := newEvent()
+		l.Handle(t.Context(), evt)
+
+		assert.False(t, evt.HandedOff())
+	})
+
+	t.Run("marks event handed off after sending to failure transport", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransport := mocks.NewMockRetryableTransport(ctrl)
+		mockTransport.EXPECT().Name().Return("test-transport").AnyTimes()
+		mockFailureTransport := mocks.NewMockTransport(ctrl)
+		mockStrategy := mocks.NewMockStrategy(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+
+		l := listener.NewSendFailedMessageForRetryListener(logger, mockTransport, mockFailureTransport, mockStrategy)
+
+		mockStrategy.EXPECT().ShouldRetry(uint(0)).Return(time.Duration(0), false)
+		mockFailureTransport.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+		evt := newEvent()
+		l.Handle(t.Context(), evt)
+
+		assert.True(t, evt.HandedOff())
+	})
+}
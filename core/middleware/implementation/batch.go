@@ -0,0 +1,178 @@
+package implementation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gerfey/messenger"
+	"github.com/gerfey/messenger/api"
+)
+
+type batchItem struct {
+	ctx  context.Context
+	env  api.Envelope
+	done func(error)
+}
+
+type batcher struct {
+	handler    api.HandlerFunc
+	mu         sync.Mutex
+	pending    []batchItem
+	timer      *time.Timer
+	generation uint64
+}
+
+func newBatcher(handler api.HandlerFunc) *batcher {
+	return &batcher{handler: handler}
+}
+
+func (b *batcher) submit(ctx context.Context, env api.Envelope, done func(error)) {
+	b.mu.Lock()
+	b.pending = append(b.pending, batchItem{ctx: ctx, env: env, done: done})
+
+	if len(b.pending) >= b.handler.BatchSize {
+		items := b.take()
+		b.mu.Unlock()
+		b.flush(items)
+
+		return
+	}
+
+	if len(b.pending) == 1 {
+		generation := b.generation
+		b.timer = time.AfterFunc(b.handler.BatchTimeout, func() {
+			b.flushPending(generation)
+		})
+	}
+	b.mu.Unlock()
+}
+
+func (b *batcher) take() []batchItem {
+	items := b.pending
+	b.pending = nil
+	b.generation++
+
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	return items
+}
+
+func (b *batcher) flushPending(generation uint64) {
+	b.mu.Lock()
+	if generation != b.generation || len(b.pending) == 0 {
+		b.mu.Unlock()
+
+		return
+	}
+	items := b.take()
+	b.mu.Unlock()
+
+	b.flush(items)
+}
+
+func (b *batcher) flush(items []batchItem) {
+	envs := make([]api.Envelope, 0, len(items))
+	for _, item := range items {
+		envs = append(envs, item.env)
+	}
+
+	ctx, cancel := batchContext(items)
+	defer cancel()
+
+	errs := callBatchHandler(ctx, b.handler, envs)
+	for i, item := range items {
+		item.done(errs[i])
+	}
+}
+
+// batchContext keeps the values of the first message's context and is cancelled as soon as
+// the worker context of any message in the batch is, so a shutdown cancels the batch
+// handler instead of waiting for it.
+func batchContext(items []batchItem) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(context.WithoutCancel(items[0].ctx))
+
+	stops := make([]func() bool, 0, len(items))
+	for _, item := range items {
+		stops = append(stops, context.AfterFunc(item.ctx, func() {
+			cancel(context.Cause(item.ctx))
+		}))
+	}
+
+	return ctx, func() {
+		for _, stop := range stops {
+			stop()
+		}
+
+		cancel(nil)
+	}
+}
+
+type batchOutcome struct {
+	mu        sync.Mutex
+	remaining int
+	errs      []error
+	done      func(error)
+}
+
+func newBatchOutcome(handlers int, done func(error)) *batchOutcome {
+	return &batchOutcome{remaining: handlers, done: done}
+}
+
+func (o *batchOutcome) complete(err error) {
+	o.mu.Lock()
+	o.remaining--
+	if err != nil {
+		o.errs = append(o.errs, err)
+	}
+	finished := o.remaining == 0
+	o.mu.Unlock()
+
+	if finished {
+		o.done(errors.Join(o.errs...))
+	}
+}
+
+func callBatchHandler(ctx context.Context, handlerFunc api.HandlerFunc, envs []api.Envelope) (errs []error) {
+	errs = make([]error, len(envs))
+
+	defer func() {
+		if r := recover(); r != nil {
+			panicErr := fmt.Errorf("batch handler panicked: %v", r)
+			for i := range errs {
+				errs[i] = panicErr
+			}
+		}
+	}()
+
+	batch := reflect.MakeSlice(handlerFunc.Fn.Type().In(1), len(envs), len(envs))
+	for i, env := range envs {
+		batch.Index(i).Set(reflect.ValueOf(env.Message()))
+	}
+
+	_, err := handlerResults(handlerFunc.Fn.Call([]reflect.Value{reflect.ValueOf(ctx), batch}))
+	if err == nil {
+		return errs
+	}
+
+	var batchErr *messenger.BatchError
+	if errors.As(err, &batchErr) {
+		for i := range errs {
+			errs[i] = batchErr.Failures[i]
+		}
+
+		return errs
+	}
+
+	for i := range errs {
+		errs[i] = err
+	}
+
+	return errs
+}
@@ -0,0 +1,212 @@
+package implementation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/ack"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/handler"
+	"github.com/gerfey/messenger/core/middleware/implementation"
+	"github.com/gerfey/messenger/core/stamps"
+	"github.com/gerfey/messenger/tests/helpers"
+)
+
+func handleReceived(t *testing.T, middleware api.Middleware, contents ...string) map[string]chan error {
+	t.Helper()
+
+	next := func(_ context.Context, env api.Envelope) (api.Envelope, error) {
+		return env, nil
+	}
+
+	results := make(map[string]chan error, len(contents))
+	for _, content := range contents {
+		result := make(chan error, 1)
+		results[content] = result
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{Content: content}).
+			WithStamp(stamps.ReceivedStamp{Transport: "kafka"}).
+			WithStamp(stamps.AckStamp{Acknowledger: ack.New(func(err error) {
+				result <- err
+			})})
+
+		_, err := middleware.Handle(t.Context(), env, next)
+		require.NoError(t, err)
+	}
+
+	return results
+}
+
+func awaitAck(t *testing.T, result chan error) error {
+	t.Helper()
+
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(time.Second):
+		t.Fatal("message was not acknowledged")
+
+		return nil
+	}
+}
+
+func TestHandleMessageMiddleware_Batch(t *testing.T) {
+	t.Run("flushes when batch size is reached", func(t *testing.T) {
+		locator := handler.NewHandlerLocator()
+		batchHandler := &helpers.TestBatchHandler{Size: 3, Timeout: time.Hour}
+		require.NoError(t, locator.Register(batchHandler))
+
+		logger, _ := helpers.NewFakeLogger()
+		middleware := implementation.NewHandleMessageMiddleware(logger, locator)
+
+		results := handleReceived(t, middleware, "a", "b", "c")
+
+		for _, result := range results {
+			require.NoError(t, awaitAck(t, result))
+		}
+		require.Len(t, batchHandler.Batches(), 1)
+		assert.Len(t, batchHandler.Batches()[0], 3)
+	})
+
+	t.Run("flushes when time window elapses", func(t *testing.T) {
+		locator := handler.NewHandlerLocator()
+		batchHandler := &helpers.TestBatchHandler{Size: 100, Timeout: 20 * time.Millisecond}
+		require.NoError(t, locator.Register(batchHandler))
+
+		logger, _ := helpers.NewFakeLogger()
+		middleware := implementation.NewHandleMessageMiddleware(logger, locator)
+
+		results := handleReceived(t, middleware, "a", "b")
+
+		for _, result := range results {
+			require.NoError(t, awaitAck(t, result))
+		}
+
+		total := 0
+		for _, batch := range batchHandler.Batches() {
+			total += len(batch)
+		}
+		assert.Equal(t, 2, total)
+	})
+
+	t.Run("fails only the reported messages", func(t *testing.T) {
+		invalid := errors.New("invalid row")
+
+		locator := handler.NewHandlerLocator()
+		batchHandler := &helpers.TestBatchHandler{
+			Size:     3,
+			Timeout:  time.Hour,
+			Failures: map[string]error{"b": invalid},
+		}
+		require.NoError(t, locator.Register(batchHandler))
+
+		logger, _ := helpers.NewFakeLogger()
+		middleware := implementation.NewHandleMessageMiddleware(logger, locator)
+
+		results := handleReceived(t, middleware, "a", "b", "c")
+
+		require.NoError(t, awaitAck(t, results["a"]))
+		require.NoError(t, awaitAck(t, results["c"]))
+
+		errB := awaitAck(t, results["b"])
+		var failed *implementation.HandlerFailedError
+		require.ErrorAs(t, errB, &failed)
+		assert.ErrorIs(t, errB, invalid)
+	})
+
+	t.Run("fails every message on a plain error", func(t *testing.T) {
+		locator := handler.NewHandlerLocator()
+		batchHandler := &helpers.TestBatchHandler{Size: 2, Timeout: time.Hour, Error: errors.New("db down")}
+		require.NoError(t, locator.Register(batchHandler))
+
+		logger, _ := helpers.NewFakeLogger()
+		middleware := implementation.NewHandleMessageMiddleware(logger, locator)
+
+		results := handleReceived(t, middleware, "a", "b")
+
+		require.Error(t, awaitAck(t, results["a"]))
+		require.Error(t, awaitAck(t, results["b"]))
+	})
+
+	t.Run("fails every message when the handler panics", func(t *testing.T) {
+		locator := handler.NewHandlerLocator()
+		batchHandler := &helpers.TestBatchHandler{Size: 2, Timeout: time.Hour, Panic: "boom"}
+		require.NoError(t, locator.Register(batchHandler))
+
+		logger, _ := helpers.NewFakeLogger()
+		middleware := implementation.NewHandleMessageMiddleware(logger, locator)
+
+		results := handleReceived(t, middleware, "a", "b")
+
+		require.ErrorContains(t, awaitAck(t, results["a"]), "boom")
+		require.ErrorContains(t, awaitAck(t, results["b"]), "boom")
+	})
+
+	t.Run("cancels the batch handler on worker shutdown", func(t *testing.T) {
+		locator := handler.NewHandlerLocator()
+		batchHandler := &helpers.TestBatchHandler{Size: 100, Timeout: 10 * time.Millisecond, Block: true}
+		require.NoError(t, locator.Register(batchHandler))
+
+		logger, _ := helpers.NewFakeLogger()
+		middleware := implementation.NewHandleMessageMiddleware(logger, locator)
+
+		ctx, cancel := context.WithCancel(t.Context())
+
+		result := make(chan error, 1)
+		env := envelope.NewEnvelope(&helpers.TestMessage{Content: "a"}).
+			WithStamp(stamps.ReceivedStamp{Transport: "kafka"}).
+			WithStamp(stamps.AckStamp{Acknowledger: ack.New(func(err error) {
+				result <- err
+			})})
+
+		_, err := middleware.Handle(ctx, env, func(_ context.Context, env api.Envelope) (api.Envelope, error) {
+			return env, nil
+		})
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool { return len(batchHandler.Batches()) == 1 }, time.Second, time.Millisecond)
+		cancel()
+
+		require.ErrorIs(t, awaitAck(t, result), context.Canceled)
+	})
+
+	t.Run("returns before the batch is full", func(t *testing.T) {
+		locator := handler.NewHandlerLocator()
+		batchHandler := &helpers.TestBatchHandler{Size: 100, Timeout: time.Hour}
+		require.NoError(t, locator.Register(batchHandler))
+
+		logger, _ := helpers.NewFakeLogger()
+		middleware := implementation.NewHandleMessageMiddleware(logger, locator)
+
+		results := handleReceived(t, middleware, "a", "b")
+
+		assert.Empty(t, batchHandler.Batches())
+		assert.Empty(t, results["a"])
+		assert.Empty(t, results["b"])
+	})
+
+	t.Run("handles dispatched messages immediately", func(t *testing.T) {
+		locator := handler.NewHandlerLocator()
+		batchHandler := &helpers.TestBatchHandler{Size: 100, Timeout: time.Hour}
+		require.NoError(t, locator.Register(batchHandler))
+
+		logger, _ := helpers.NewFakeLogger()
+		middleware := implementation.NewHandleMessageMiddleware(logger, locator)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{Content: "a"})
+		result, err := middleware.Handle(t.Context(), env, func(_ context.Context, env api.Envelope) (api.Envelope, error) {
+			return env, nil
+		})
+
+		require.NoError(t, err)
+		assert.True(t, envelope.HasStampOf[stamps.HandledStamp](result))
+		require.Len(t, batchHandler.Batches(), 1)
+		assert.Len(t, batchHandler.Batches()[0], 1)
+	})
+}
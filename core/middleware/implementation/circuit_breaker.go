@@ -0,0 +1,239 @@
+package implementation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gerfey/messenger"
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/ack"
+	"github.com/gerfey/messenger/core/circuitbreaker"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/event"
+	"github.com/gerfey/messenger/core/stamps"
+)
+
+type CircuitBreakerMiddleware struct {
+	logger          *slog.Logger
+	handlersLocator api.HandlerLocator
+	senderLocator   api.SenderLocator
+	eventDispatcher api.EventDispatcher
+	config          circuitbreaker.Config
+	mu              sync.Mutex
+	breakers        map[string]*circuitbreaker.Breaker
+}
+
+func NewCircuitBreakerMiddleware(
+	logger *slog.Logger,
+	handlersLocator api.HandlerLocator,
+	senderLocator api.SenderLocator,
+	eventDispatcher api.EventDispatcher,
+	config circuitbreaker.Config,
+) api.Middleware {
+	return &CircuitBreakerMiddleware{
+		logger:          logger,
+		handlersLocator: handlersLocator,
+		senderLocator:   senderLocator,
+		eventDispatcher: eventDispatcher,
+		config:          config,
+		breakers:        make(map[string]*circuitbreaker.Breaker),
+	}
+}
+
+func (m *CircuitBreakerMiddleware) Handle(
+	ctx context.Context,
+	env api.Envelope,
+	next api.NextFunc,
+) (api.Envelope, error) {
+	_, received := envelope.LastStampOf[stamps.ReceivedStamp](env)
+
+	if !received {
+		if senders := m.senderLocator.GetSenders(env); len(senders) > 0 {
+			return m.handleSenders(ctx, env, senders, next)
+		}
+	}
+
+	return m.handleHandlers(ctx, env, received, next)
+}
+
+func (m *CircuitBreakerMiddleware) handleSenders(
+	ctx context.Context,
+	env api.Envelope,
+	senders []api.Sender,
+	next api.NextFunc,
+) (api.Envelope, error) {
+	breakers := make([]*circuitbreaker.Breaker, 0, len(senders))
+	for _, sender := range senders {
+		breakers = append(breakers, m.breaker(sender.Name()))
+	}
+
+	if name, _, ok := allow(ctx, breakers); !ok {
+		return nil, fmt.Errorf("sender %s: %w", name, circuitbreaker.ErrOpen)
+	}
+
+	result, err := next(ctx, env)
+	if err != nil {
+		var senderErr *SenderFailedError
+		if !errors.As(err, &senderErr) {
+			senderErr = &SenderFailedError{}
+		}
+
+		// Senders run in order, so the ones before the failed sender got the message
+		// and the ones after it were never tried.
+		sent := true
+		for i, b := range breakers {
+			switch {
+			case senders[i].Name() == senderErr.Sender:
+				b.Failure(ctx)
+				sent = false
+			case sent && senderErr.Sender != "":
+				b.Success(ctx)
+			default:
+				b.Release()
+			}
+		}
+
+		return result, err
+	}
+
+	for _, b := range breakers {
+		b.Success(ctx)
+	}
+
+	return result, nil
+}
+
+func (m *CircuitBreakerMiddleware) handleHandlers(
+	ctx context.Context,
+	env api.Envelope,
+	received bool,
+	next api.NextFunc,
+) (api.Envelope, error) {
+	handlers := m.handlersLocator.Get(env.Message())
+
+	breakers := make([]*circuitbreaker.Breaker, 0, len(handlers))
+	for _, h := range handlers {
+		breakers = append(breakers, m.breaker(h.HandlerStr))
+	}
+
+	if name, wait, ok := allow(ctx, breakers); !ok {
+		err := fmt.Errorf("handler %s: %w", name, circuitbreaker.ErrOpen)
+
+		m.logger.WarnContext(ctx, "circuit breaker rejected message", "handler", name, "retry_after", wait)
+
+		if received {
+			return nil, messenger.RecoverableAfter(err, wait)
+		}
+
+		return nil, err
+	}
+
+	ackStamp, ok := envelope.LastStampOf[stamps.AckStamp](env)
+	if !ok || ackStamp.Acknowledger == nil {
+		result, err := next(ctx, env)
+		recordHandlers(ctx, breakers, err)
+
+		return result, err
+	}
+
+	// Batch handlers finish a message after next returns, so the outcome is recorded
+	// once the message is acknowledged.
+	acknowledger := ack.New(func(err error) {
+		recordHandlers(context.WithoutCancel(ctx), breakers, err)
+		ackStamp.Acknowledger.Ack(err)
+	})
+
+	result, err := next(ctx, env.WithStamp(stamps.AckStamp{Acknowledger: acknowledger}))
+	if acknowledger.Deferred() {
+		ackStamp.Acknowledger.Defer()
+
+		return result, err
+	}
+
+	recordHandlers(ctx, breakers, err)
+
+	return result, err
+}
+
+func (m *CircuitBreakerMiddleware) breaker(name string) *circuitbreaker.Breaker {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, ok := m.breakers[name]
+	if !ok {
+		b = circuitbreaker.NewBreaker(name, m.config, func(ctx context.Context, name string, from, to circuitbreaker.State) {
+			m.logger.InfoContext(ctx, "circuit breaker state changed", "name", name, "from", from, "to", to)
+
+			errDispatch := m.eventDispatcher.Dispatch(ctx, event.CircuitBreakerStateChangedEvent{
+				Ctx:  ctx,
+				Name: name,
+				From: from,
+				To:   to,
+			})
+			if errDispatch != nil {
+				m.logger.ErrorContext(ctx, "failed to dispatch circuit breaker event", "error", errDispatch)
+			}
+		})
+		m.breakers[name] = b
+	}
+
+	return b
+}
+
+func allow(ctx context.Context, breakers []*circuitbreaker.Breaker) (string, time.Duration, bool) {
+	for i, b := range breakers {
+		if wait, ok := b.Allow(ctx); !ok {
+			for _, allowed := range breakers[:i] {
+				allowed.Release()
+			}
+
+			return b.Name(), wait, false
+		}
+	}
+
+	return "", 0, true
+}
+
+// recordHandlers records a failure on every handler that failed with a recoverable error
+// and releases the others, or records a success on all of them.
+func recordHandlers(ctx context.Context, breakers []*circuitbreaker.Breaker, err error) {
+	if err == nil {
+		for _, b := range breakers {
+			b.Success(ctx)
+		}
+
+		return
+	}
+
+	failed := make(map[string]bool)
+	failedHandlers(err, failed)
+
+	for _, b := range breakers {
+		if failed[b.Name()] {
+			b.Failure(ctx)
+		} else {
+			b.Release()
+		}
+	}
+}
+
+func failedHandlers(err error, failed map[string]bool) {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			failedHandlers(e, failed)
+		}
+
+		return
+	}
+
+	var handlerErr *HandlerFailedError
+	var unrecoverable *messenger.UnrecoverableError
+
+	if errors.As(err, &handlerErr) && !errors.As(err, &unrecoverable) {
+		failed[handlerErr.Handler] = true
+	}
+}
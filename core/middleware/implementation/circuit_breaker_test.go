@@ -0,0 +1,259 @@
+package implementation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/gerfey/messenger"
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/ack"
+	"github.com/gerfey/messenger/core/circuitbreaker"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/event"
+	"github.com/gerfey/messenger/core/handler"
+	"github.com/gerfey/messenger/core/middleware/implementation"
+	"github.com/gerfey/messenger/core/stamps"
+	"github.com/gerfey/messenger/tests/helpers"
+	"github.com/gerfey/messenger/tests/mocks"
+)
+
+func TestCircuitBreakerMiddleware_Handle(t *testing.T) {
+	setup := func(t *testing.T, handlerErr error) (api.Middleware, *mocks.MockEventDispatcher, api.NextFunc) {
+		t.Helper()
+
+		ctrl := gomock.NewController(t)
+		logger, _ := helpers.NewFakeLogger()
+
+		handlersLocator := handler.NewHandlerLocator()
+		h := &helpers.ErrorTestMessageHandler{Error: handlerErr}
+		require.NoError(t, handlersLocator.Register(h))
+
+		senderLocator := mocks.NewMockSenderLocator(ctrl)
+		senderLocator.EXPECT().GetSenders(gomock.Any()).Return(nil).AnyTimes()
+
+		eventDispatcher := mocks.NewMockEventDispatcher(ctrl)
+
+		mw := implementation.NewCircuitBreakerMiddleware(
+			logger,
+			handlersLocator,
+			senderLocator,
+			eventDispatcher,
+			circuitbreaker.Config{FailureThreshold: 2, OpenTimeout: time.Minute},
+		)
+
+		next := implementation.NewHandleMessageMiddleware(logger, handlersLocator)
+		nextFunc := func(ctx context.Context, env api.Envelope) (api.Envelope, error) {
+			return next.Handle(ctx, env, func(_ context.Context, env api.Envelope) (api.Envelope, error) {
+				return env, nil
+			})
+		}
+
+		return mw, eventDispatcher, nextFunc
+	}
+
+	t.Run("opens after handler failures and requeues with delay", func(t *testing.T) {
+		mw, eventDispatcher, next := setup(t, errors.New("database is down"))
+
+		eventDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, evt any) error {
+				changed, ok := evt.(event.CircuitBreakerStateChangedEvent)
+				require.True(t, ok)
+				assert.Equal(t, circuitbreaker.StateClosed, changed.From)
+				assert.Equal(t, circuitbreaker.StateOpen, changed.To)
+
+				return nil
+			},
+		)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{ID: "1"}).
+			WithStamp(stamps.ReceivedStamp{Transport: "async"})
+
+		for range 2 {
+			_, err := mw.Handle(t.Context(), env, next)
+			require.Error(t, err)
+
+			var handlerErr *implementation.HandlerFailedError
+			require.ErrorAs(t, err, &handlerErr)
+		}
+
+		_, err := mw.Handle(t.Context(), env, next)
+		require.ErrorIs(t, err, circuitbreaker.ErrOpen)
+
+		var recoverable *messenger.RecoverableError
+		require.ErrorAs(t, err, &recoverable)
+		assert.Greater(t, recoverable.Delay, time.Duration(0))
+	})
+
+	t.Run("fails fast on dispatch when open", func(t *testing.T) {
+		mw, eventDispatcher, next := setup(t, errors.New("database is down"))
+
+		eventDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.Any()).Return(nil)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{ID: "1"})
+
+		for range 2 {
+			_, _ = mw.Handle(t.Context(), env, next)
+		}
+
+		_, err := mw.Handle(t.Context(), env, next)
+		require.ErrorIs(t, err, circuitbreaker.ErrOpen)
+
+		var recoverable *messenger.RecoverableError
+		assert.NotErrorAs(t, err, &recoverable)
+	})
+
+	t.Run("ignores unrecoverable errors", func(t *testing.T) {
+		mw, _, next := setup(t, messenger.Unrecoverable(errors.New("invalid payload")))
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{ID: "1"}).
+			WithStamp(stamps.ReceivedStamp{Transport: "async"})
+
+		for range 3 {
+			_, err := mw.Handle(t.Context(), env, next)
+			require.Error(t, err)
+			assert.NotErrorIs(t, err, circuitbreaker.ErrOpen)
+		}
+	})
+
+	t.Run("tracks senders on dispatch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		logger, _ := helpers.NewFakeLogger()
+
+		sender := mocks.NewMockSender(ctrl)
+		sender.EXPECT().Name().Return("amqp").AnyTimes()
+
+		senderLocator := mocks.NewMockSenderLocator(ctrl)
+		senderLocator.EXPECT().GetSenders(gomock.Any()).Return([]api.Sender{sender}).AnyTimes()
+
+		eventDispatcher := mocks.NewMockEventDispatcher(ctrl)
+		eventDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, evt any) error {
+				changed, ok := evt.(event.CircuitBreakerStateChangedEvent)
+				require.True(t, ok)
+				assert.Equal(t, "amqp", changed.Name)
+
+				return nil
+			},
+		)
+
+		mw := implementation.NewCircuitBreakerMiddleware(
+			logger,
+			handler.NewHandlerLocator(),
+			senderLocator,
+			eventDispatcher,
+			circuitbreaker.Config{FailureThreshold: 1, OpenTimeout: time.Minute},
+		)
+
+		calls := 0
+		next := func(_ context.Context, _ api.Envelope) (api.Envelope, error) {
+			calls++
+
+			return nil, &implementation.SenderFailedError{Sender: "amqp", Err: errors.New("connection refused")}
+		}
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{ID: "1"})
+
+		_, err := mw.Handle(t.Context(), env, next)
+		require.Error(t, err)
+
+		_, err = mw.Handle(t.Context(), env, next)
+		require.ErrorIs(t, err, circuitbreaker.ErrOpen)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("trips only the failed sender", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		logger, _ := helpers.NewFakeLogger()
+
+		healthy := mocks.NewMockSender(ctrl)
+		healthy.EXPECT().Name().Return("redis").AnyTimes()
+
+		failing := mocks.NewMockSender(ctrl)
+		failing.EXPECT().Name().Return("amqp").AnyTimes()
+
+		senderLocator := mocks.NewMockSenderLocator(ctrl)
+
+		eventDispatcher := mocks.NewMockEventDispatcher(ctrl)
+		eventDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, evt any) error {
+				changed, ok := evt.(event.CircuitBreakerStateChangedEvent)
+				require.True(t, ok)
+				assert.Equal(t, "amqp", changed.Name)
+
+				return nil
+			},
+		)
+
+		mw := implementation.NewCircuitBreakerMiddleware(
+			logger,
+			handler.NewHandlerLocator(),
+			senderLocator,
+			eventDispatcher,
+			circuitbreaker.Config{FailureThreshold: 1, OpenTimeout: time.Minute},
+		)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{ID: "1"})
+
+		senderLocator.EXPECT().GetSenders(gomock.Any()).Return([]api.Sender{healthy, failing})
+		_, err := mw.Handle(t.Context(), env, func(_ context.Context, _ api.Envelope) (api.Envelope, error) {
+			return nil, &implementation.SenderFailedError{Sender: "amqp", Err: errors.New("connection refused")}
+		})
+		require.Error(t, err)
+
+		senderLocator.EXPECT().GetSenders(gomock.Any()).Return([]api.Sender{healthy})
+		_, err = mw.Handle(t.Context(), env, func(_ context.Context, env api.Envelope) (api.Envelope, error) {
+			return env, nil
+		})
+		require.NoError(t, err)
+
+		senderLocator.EXPECT().GetSenders(gomock.Any()).Return([]api.Sender{failing})
+		_, err = mw.Handle(t.Context(), env, func(_ context.Context, env api.Envelope) (api.Envelope, error) {
+			return env, nil
+		})
+		require.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	})
+
+	t.Run("records deferred outcomes on acknowledgement", func(t *testing.T) {
+		mw, eventDispatcher, handle := setup(t, errors.New("database is down"))
+
+		eventDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.Any()).Return(nil)
+
+		var acked []error
+		next := func(ctx context.Context, env api.Envelope) (api.Envelope, error) {
+			ackStamp, ok := envelope.LastStampOf[stamps.AckStamp](env)
+			require.True(t, ok)
+
+			ackStamp.Acknowledger.Defer()
+
+			_, err := handle(ctx, env)
+			ackStamp.Acknowledger.Ack(err)
+
+			return env, nil
+		}
+
+		for range 2 {
+			transportAck := ack.New(func(err error) { acked = append(acked, err) })
+			env := envelope.NewEnvelope(&helpers.TestMessage{ID: "1"}).
+				WithStamp(stamps.ReceivedStamp{Transport: "async"}).
+				WithStamp(stamps.AckStamp{Acknowledger: transportAck})
+
+			_, err := mw.Handle(t.Context(), env, next)
+			require.NoError(t, err)
+			assert.True(t, transportAck.Deferred())
+		}
+
+		require.Len(t, acked, 2)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{ID: "1"}).
+			WithStamp(stamps.ReceivedStamp{Transport: "async"})
+
+		_, err := mw.Handle(t.Context(), env, next)
+		require.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	})
+}
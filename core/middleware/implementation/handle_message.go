@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"log/slog"
 	"reflect"
+	"sync"
 
 	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
 	"github.com/gerfey/messenger/core/stamps"
 )
 
@@ -17,12 +19,15 @@ const (
 type HandleMessageMiddleware struct {
 	logger          *slog.Logger
 	handlersLocator api.HandlerLocator
+	batchers        map[string]*batcher
+	mu              sync.Mutex
 }
 
 func NewHandleMessageMiddleware(logger *slog.Logger, handlersLocator api.HandlerLocator) api.Middleware {
 	return &HandleMessageMiddleware{
 		logger:          logger,
 		handlersLocator: handlersLocator,
+		batchers:        make(map[string]*batcher),
 	}
 }
 
@@ -51,44 +56,136 @@ func (h *HandleMessageMiddleware) Handle(
 		len(handlers),
 	)
 
+	var batched []api.HandlerFunc
+
 	for _, handlerFunc := range handlers {
-		results := handlerFunc.Fn.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(msg)})
+		if handlerFunc.Batch {
+			batched = append(batched, handlerFunc)
+
+			continue
+		}
+
+		result, err := handlerResults(
+			handlerFunc.Fn.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(msg)}),
+		)
+		if err != nil {
+			return nil, h.handlerFailed(ctx, handlerFunc, msgType, err)
+		}
 
-		var result any
+		env = h.handled(ctx, env, handlerFunc, msgType, result)
+	}
+
+	if len(batched) > 0 {
 		var err error
+		if env, err = h.handleBatched(ctx, batched, env); err != nil {
+			return nil, err
+		}
+	}
 
-		if len(results) == 1 {
-			if e, ok := results[0].Interface().(error); ok {
-				err = e
-			} else {
-				result = results[0].Interface()
-			}
-		} else if len(results) == expectedResultsWithError {
-			result = results[0].Interface()
-			if e, ok := results[1].Interface().(error); ok {
-				err = e
+	return next(ctx, env)
+}
+
+func (h *HandleMessageMiddleware) handleBatched(
+	ctx context.Context,
+	handlers []api.HandlerFunc,
+	env api.Envelope,
+) (api.Envelope, error) {
+	msgType := reflect.TypeOf(env.Message())
+
+	ackStamp, ok := envelope.LastStampOf[stamps.AckStamp](env)
+	if !ok || ackStamp.Acknowledger == nil {
+		for _, handlerFunc := range handlers {
+			if err := callBatchHandler(ctx, handlerFunc, []api.Envelope{env})[0]; err != nil {
+				return nil, h.handlerFailed(ctx, handlerFunc, msgType, err)
 			}
+
+			env = h.handled(ctx, env, handlerFunc, msgType, nil)
 		}
 
-		if err != nil {
-			h.logger.ErrorContext(ctx, "handler failed",
-				"handler", handlerFunc.HandlerStr,
-				"message_type", msgType.String(),
-				"error", err)
+		return env, nil
+	}
 
-			return nil, fmt.Errorf("handler %s failed for message type %T: %w", handlerFunc.HandlerStr, msg, err)
-		}
+	ackStamp.Acknowledger.Defer()
+	outcome := newBatchOutcome(len(handlers), ackStamp.Acknowledger.Ack)
 
-		env = env.WithStamp(stamps.HandledStamp{
-			Handler:    handlerFunc.HandlerStr,
-			Result:     result,
-			ResultType: reflect.TypeOf(result),
+	for _, handlerFunc := range handlers {
+		h.batcher(handlerFunc).submit(ctx, env, func(err error) {
+			if err != nil {
+				err = h.handlerFailed(ctx, handlerFunc, msgType, err)
+			}
+
+			outcome.complete(err)
 		})
+	}
+
+	return env, nil
+}
+
+func (h *HandleMessageMiddleware) batcher(handlerFunc api.HandlerFunc) *batcher {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 
-		h.logger.DebugContext(ctx, "message handled successfully",
-			"handler", handlerFunc.HandlerStr,
-			"message_type", msgType.String())
+	b, ok := h.batchers[handlerFunc.HandlerStr]
+	if !ok {
+		b = newBatcher(handlerFunc)
+		h.batchers[handlerFunc.HandlerStr] = b
 	}
 
-	return next(ctx, env)
+	return b
+}
+
+func (h *HandleMessageMiddleware) handled(
+	ctx context.Context,
+	env api.Envelope,
+	handlerFunc api.HandlerFunc,
+	msgType reflect.Type,
+	result any,
+) api.Envelope {
+	h.logger.DebugContext(ctx, "message handled successfully",
+		"handler", handlerFunc.HandlerStr,
+		"message_type", msgType.String())
+
+	return env.WithStamp(stamps.HandledStamp{
+		Handler:    handlerFunc.HandlerStr,
+		Result:     result,
+		ResultType: reflect.TypeOf(result),
+	})
+}
+
+func (h *HandleMessageMiddleware) handlerFailed(
+	ctx context.Context,
+	handlerFunc api.HandlerFunc,
+	msgType reflect.Type,
+	err error,
+) error {
+	h.logger.ErrorContext(ctx, "handler failed",
+		"handler", handlerFunc.HandlerStr,
+		"message_type", msgType.String(),
+		"error", err)
+
+	return &HandlerFailedError{
+		Handler:     handlerFunc.HandlerStr,
+		MessageType: msgType.String(),
+		Err:         err,
+	}
+}
+
+func handlerResults(results []reflect.Value) (any, error) {
+	var result any
+	var err error
+
+	if len(results) == 1 {
+		if e, ok := results[0].Interface().(error); ok {
+			err = e
+		} else {
+			result = results[0].Interface()
+		}
+	} else if len(results) == expectedResultsWithError {
+		result = results[0].Interface()
+		if e, ok := results[1].Interface().(error); ok {
+			err = e
+		}
+	}
+
+	return result, err
 }
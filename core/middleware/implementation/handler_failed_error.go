@@ -0,0 +1,17 @@
+package implementation
+
+import "fmt"
+
+type HandlerFailedError struct {
+	Handler     string
+	MessageType string
+	Err         error
+}
+
+func (e *HandlerFailedError) Error() string {
+	return fmt.Sprintf("handler %s failed for message type %s: %s", e.Handler, e.MessageType, e.Err)
+}
+
+func (e *HandlerFailedError) Unwrap() error {
+	return e.Err
+}
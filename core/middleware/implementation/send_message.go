@@ -2,12 +2,14 @@ package implementation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/gerfey/messenger/api"
 	"github.com/gerfey/messenger/core/envelope"
 	"github.com/gerfey/messenger/core/event"
+	"github.com/gerfey/messenger/core/rpc"
 	"github.com/gerfey/messenger/core/stamps"
 )
 
@@ -46,6 +48,10 @@ func (m *SendMessageMiddleware) Handle(ctx context.Context, env api.Envelope, ne
 		return env, fmt.Errorf("no senders configured for message %T", msg)
 	}
 
+	if envelope.HasStampOf[stamps.RequestStamp](env) {
+		return m.request(ctx, env, senders)
+	}
+
 	var isSent = false
 
 	for _, sender := range senders {
@@ -72,7 +78,7 @@ func (m *SendMessageMiddleware) Handle(ctx context.Context, env api.Envelope, ne
 				"sender", sender.Name(),
 				"error", err)
 
-			return nil, err
+			return nil, &SenderFailedError{Sender: sender.Name(), Err: err}
 		}
 
 		isSent = true
@@ -86,3 +92,51 @@ func (m *SendMessageMiddleware) Handle(ctx context.Context, env api.Envelope, ne
 
 	return env, nil
 }
+
+func (m *SendMessageMiddleware) request(
+	ctx context.Context,
+	env api.Envelope,
+	senders []api.Sender,
+) (api.Envelope, error) {
+	for _, sender := range senders {
+		requestSender, ok := sender.(api.RequestSender)
+		if !ok {
+			continue
+		}
+
+		errDispatcher := m.eventDispatcher.Dispatch(ctx, &event.SendMessageToTransportsEvent{
+			Ctx:      ctx,
+			Envelope: env,
+			Senders:  []api.Sender{sender},
+		})
+		if errDispatcher != nil {
+			m.logger.ErrorContext(ctx, "failed to dispatch send event", "error", errDispatcher)
+
+			return nil, errDispatcher
+		}
+
+		sentEnv := env.WithStamp(stamps.SentStamp{SenderName: sender.Name()})
+
+		reply, err := requestSender.Request(ctx, sentEnv)
+		if errors.Is(err, rpc.ErrRequestNotSupported) {
+			m.logger.DebugContext(ctx, "sender does not support requests", "sender", sender.Name(), "error", err)
+
+			continue
+		}
+
+		if err != nil {
+			m.logger.ErrorContext(ctx, "request to sender failed", "sender", sender.Name(), "error", err)
+
+			return nil, &SenderFailedError{Sender: sender.Name(), Err: err}
+		}
+
+		handledStamp, err := rpc.Result(reply)
+		if err != nil {
+			return nil, err
+		}
+
+		return sentEnv.WithStamp(handledStamp), nil
+	}
+
+	return env, fmt.Errorf("%w %T", rpc.ErrRequestNotSupported, env.Message())
+}
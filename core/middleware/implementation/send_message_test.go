@@ -12,6 +12,7 @@ import (
 	"github.com/gerfey/messenger/api"
 	"github.com/gerfey/messenger/core/envelope"
 	"github.com/gerfey/messenger/core/middleware/implementation"
+	"github.com/gerfey/messenger/core/rpc"
 	"github.com/gerfey/messenger/core/stamps"
 	"github.com/gerfey/messenger/tests/helpers"
 	"github.com/gerfey/messenger/tests/mocks"
@@ -246,7 +247,7 @@ func TestSendMessageMiddleware_Handle(t *testing.T) {
 		sendError := errors.New("send error")
 
 		mockTransportLocator.EXPECT().GetSenders(env).Return([]api.Sender{mockTransport})
-		mockTransport.EXPECT().Name().Return("test-transport").Times(3)
+		mockTransport.EXPECT().Name().Return("test-transport").Times(4)
 		mockEventDispatcher.EXPECT().Dispatch(t.Context(), gomock.Any()).Return(nil)
 		mockTransport.EXPECT().Send(t.Context(), gomock.Any()).Return(sendError)
 
@@ -259,8 +260,99 @@ func TestSendMessageMiddleware_Handle(t *testing.T) {
 
 		_, err := middleware.Handle(t.Context(), env, next)
 
-		require.Error(t, err)
-		assert.Equal(t, sendError, err)
+		require.ErrorIs(t, err, sendError)
+
+		var senderErr *implementation.SenderFailedError
+		require.ErrorAs(t, err, &senderErr)
+		assert.Equal(t, "test-transport", senderErr.Sender)
 		assert.False(t, nextCalled)
 	})
 }
+
+func TestSendMessageMiddleware_Request(t *testing.T) {
+	t.Run("request goes to the first request sender", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransportLocator := mocks.NewMockSenderLocator(ctrl)
+		mockEventDispatcher := mocks.NewMockEventDispatcher(ctrl)
+		mockSender := mocks.NewMockSender(ctrl)
+		mockRequestSender := mocks.NewMockRequestSender(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+		middleware := implementation.NewSendMessageMiddleware(logger, mockTransportLocator, mockEventDispatcher)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{Content: "ping"}).
+			WithStamp(stamps.RequestStamp{CorrelationID: "42"})
+		reply := envelope.NewEnvelope(&helpers.TestMessage{Content: "pong"}).
+			WithStamp(stamps.ReplyStamp{CorrelationID: "42", Handler: "handler"})
+
+		mockTransportLocator.EXPECT().GetSenders(env).Return([]api.Sender{mockSender, mockRequestSender})
+		mockRequestSender.EXPECT().Name().Return("rpc").AnyTimes()
+		mockEventDispatcher.EXPECT().Dispatch(t.Context(), gomock.Any()).Return(nil)
+		mockRequestSender.EXPECT().Request(t.Context(), gomock.Any()).Return(reply, nil)
+
+		result, err := middleware.Handle(t.Context(), env, func(_ context.Context, env api.Envelope) (api.Envelope, error) {
+			return env, nil
+		})
+
+		require.NoError(t, err)
+		handledStamp, ok := envelope.LastStampOf[stamps.HandledStamp](result)
+		require.True(t, ok)
+		assert.Equal(t, "handler", handledStamp.Handler)
+		assert.Equal(t, &helpers.TestMessage{Content: "pong"}, handledStamp.Result)
+	})
+
+	t.Run("request skips senders that cannot request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransportLocator := mocks.NewMockSenderLocator(ctrl)
+		mockEventDispatcher := mocks.NewMockEventDispatcher(ctrl)
+		unsupported := mocks.NewMockRequestSender(ctrl)
+		mockRequestSender := mocks.NewMockRequestSender(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+		middleware := implementation.NewSendMessageMiddleware(logger, mockTransportLocator, mockEventDispatcher)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{Content: "ping"}).
+			WithStamp(stamps.RequestStamp{CorrelationID: "42"})
+		reply := envelope.NewEnvelope(&helpers.TestMessage{Content: "pong"}).
+			WithStamp(stamps.ReplyStamp{CorrelationID: "42", Handler: "handler"})
+
+		mockTransportLocator.EXPECT().GetSenders(env).Return([]api.Sender{unsupported, mockRequestSender})
+		unsupported.EXPECT().Name().Return("kafka").AnyTimes()
+		mockRequestSender.EXPECT().Name().Return("rpc").AnyTimes()
+		mockEventDispatcher.EXPECT().Dispatch(t.Context(), gomock.Any()).Return(nil).Times(2)
+		unsupported.EXPECT().Request(t.Context(), gomock.Any()).Return(nil, rpc.ErrRequestNotSupported)
+		mockRequestSender.EXPECT().Request(t.Context(), gomock.Any()).Return(reply, nil)
+
+		result, err := middleware.Handle(t.Context(), env, func(_ context.Context, env api.Envelope) (api.Envelope, error) {
+			return env, nil
+		})
+
+		require.NoError(t, err)
+		sentStamp, ok := envelope.LastStampOf[stamps.SentStamp](result)
+		require.True(t, ok)
+		assert.Equal(t, "rpc", sentStamp.SenderName)
+	})
+
+	t.Run("request fails without request sender", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransportLocator := mocks.NewMockSenderLocator(ctrl)
+		mockEventDispatcher := mocks.NewMockEventDispatcher(ctrl)
+		mockSender := mocks.NewMockSender(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+		middleware := implementation.NewSendMessageMiddleware(logger, mockTransportLocator, mockEventDispatcher)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{}).WithStamp(stamps.RequestStamp{CorrelationID: "42"})
+
+		mockTransportLocator.EXPECT().GetSenders(env).Return([]api.Sender{mockSender})
+
+		_, err := middleware.Handle(t.Context(), env, func(_ context.Context, env api.Envelope) (api.Envelope, error) {
+			return env, nil
+		})
+
+		require.ErrorIs(t, err, rpc.ErrRequestNotSupported)
+	})
+}
@@ -0,0 +1,65 @@
+package implementation
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/gerfey/messenger"
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/rpc"
+	"github.com/gerfey/messenger/core/stamps"
+)
+
+type SendReplyMiddleware struct {
+	logger        *slog.Logger
+	senderLocator api.SenderLocator
+}
+
+func NewSendReplyMiddleware(logger *slog.Logger, senderLocator api.SenderLocator) api.Middleware {
+	return &SendReplyMiddleware{
+		logger:        logger,
+		senderLocator: senderLocator,
+	}
+}
+
+func (m *SendReplyMiddleware) Handle(ctx context.Context, env api.Envelope, next api.NextFunc) (api.Envelope, error) {
+	receivedStamp, received := envelope.LastStampOf[stamps.ReceivedStamp](env)
+	if !received || !envelope.HasStampOf[stamps.RequestStamp](env) {
+		return next(ctx, env)
+	}
+
+	replySender, ok := m.replySender(env, receivedStamp.Transport)
+	if !ok {
+		return next(ctx, env)
+	}
+
+	handled, err := next(ctx, env)
+
+	var unrecoverable *messenger.UnrecoverableError
+	if err != nil && !errors.As(err, &unrecoverable) {
+		return handled, err
+	}
+
+	if replyErr := replySender.Reply(ctx, env, rpc.NewReply(env, handled, err)); replyErr != nil {
+		m.logger.ErrorContext(ctx, "failed to send reply",
+			"transport", receivedStamp.Transport,
+			"error", replyErr)
+	}
+
+	return handled, err
+}
+
+func (m *SendReplyMiddleware) replySender(env api.Envelope, transportName string) (api.ReplySender, bool) {
+	lookup := envelope.NewEnvelope(env.Message()).
+		WithStamp(stamps.TransportNameStamp{Transports: []string{transportName}})
+
+	for _, sender := range m.senderLocator.GetSenders(lookup) {
+		if replySender, ok := sender.(api.ReplySender); ok && sender.Name() == transportName {
+			return replySender, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,120 @@
+package implementation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/gerfey/messenger"
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/middleware/implementation"
+	"github.com/gerfey/messenger/core/stamps"
+	"github.com/gerfey/messenger/tests/helpers"
+	"github.com/gerfey/messenger/tests/mocks"
+)
+
+func TestSendReplyMiddleware_Handle(t *testing.T) {
+	request := envelope.NewEnvelope(&helpers.TestMessage{Content: "ping"}).
+		WithStamp(stamps.RequestStamp{CorrelationID: "42", ReplyTo: "replies"}).
+		WithStamp(stamps.ReceivedStamp{Transport: "rpc"})
+
+	handle := func(result any, err error) api.NextFunc {
+		return func(_ context.Context, env api.Envelope) (api.Envelope, error) {
+			if err != nil {
+				return nil, err
+			}
+
+			return env.WithStamp(stamps.HandledStamp{Handler: "handler", Result: result}), nil
+		}
+	}
+
+	t.Run("replies with handler result", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransportLocator := mocks.NewMockSenderLocator(ctrl)
+		mockReplySender := mocks.NewMockReplySender(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+		middleware := implementation.NewSendReplyMiddleware(logger, mockTransportLocator)
+
+		mockTransportLocator.EXPECT().GetSenders(gomock.Any()).Return([]api.Sender{mockReplySender})
+		mockReplySender.EXPECT().Name().Return("rpc").AnyTimes()
+		mockReplySender.EXPECT().Reply(t.Context(), request, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ api.Envelope, reply api.Envelope) error {
+				assert.Equal(t, "pong", reply.Message())
+				replyStamp, _ := envelope.LastStampOf[stamps.ReplyStamp](reply)
+				assert.Equal(t, stamps.ReplyStamp{CorrelationID: "42", Handler: "handler"}, replyStamp)
+
+				return nil
+			})
+
+		_, err := middleware.Handle(t.Context(), request, handle("pong", nil))
+
+		require.NoError(t, err)
+	})
+
+	t.Run("replies with unrecoverable error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransportLocator := mocks.NewMockSenderLocator(ctrl)
+		mockReplySender := mocks.NewMockReplySender(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+		middleware := implementation.NewSendReplyMiddleware(logger, mockTransportLocator)
+
+		handlerErr := messenger.Unrecoverable(errors.New("not found"))
+
+		mockTransportLocator.EXPECT().GetSenders(gomock.Any()).Return([]api.Sender{mockReplySender})
+		mockReplySender.EXPECT().Name().Return("rpc").AnyTimes()
+		mockReplySender.EXPECT().Reply(t.Context(), request, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ api.Envelope, reply api.Envelope) error {
+				replyStamp, _ := envelope.LastStampOf[stamps.ReplyStamp](reply)
+				assert.Equal(t, "not found", replyStamp.Error)
+
+				return nil
+			})
+
+		_, err := middleware.Handle(t.Context(), request, handle(nil, handlerErr))
+
+		require.ErrorIs(t, err, handlerErr)
+	})
+
+	t.Run("does not reply to recoverable failures", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransportLocator := mocks.NewMockSenderLocator(ctrl)
+		mockReplySender := mocks.NewMockReplySender(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+		middleware := implementation.NewSendReplyMiddleware(logger, mockTransportLocator)
+
+		handlerErr := errors.New("temporary")
+
+		mockTransportLocator.EXPECT().GetSenders(gomock.Any()).Return([]api.Sender{mockReplySender})
+		mockReplySender.EXPECT().Name().Return("rpc").AnyTimes()
+
+		_, err := middleware.Handle(t.Context(), request, handle(nil, handlerErr))
+
+		require.ErrorIs(t, err, handlerErr)
+	})
+
+	t.Run("skips messages that are not requests", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTransportLocator := mocks.NewMockSenderLocator(ctrl)
+		logger, _ := helpers.NewFakeLogger()
+		middleware := implementation.NewSendReplyMiddleware(logger, mockTransportLocator)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{}).WithStamp(stamps.ReceivedStamp{Transport: "rpc"})
+
+		_, err := middleware.Handle(t.Context(), env, handle("pong", nil))
+
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,16 @@
+package implementation
+
+import "fmt"
+
+type SenderFailedError struct {
+	Sender string
+	Err    error
+}
+
+func (e *SenderFailedError) Error() string {
+	return fmt.Sprintf("sender %s failed: %s", e.Sender, e.Err)
+}
+
+func (e *SenderFailedError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,123 @@
+package retry
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	JitterNone         = "none"
+	JitterFull         = "full"
+	JitterEqual        = "equal"
+	JitterDecorrelated = "decorrelated"
+
+	defaultGrowth = 2
+)
+
+type ExponentialRetryStrategy struct {
+	MaxRetries uint
+	Delay      time.Duration
+	Multiplier float64
+	MaxDelay   time.Duration
+	Jitter     string
+}
+
+func NewExponentialRetryStrategy(
+	maxRetries uint,
+	delay time.Duration,
+	multiplier float64,
+	maxDelay time.Duration,
+	jitter string,
+) Strategy {
+	return &ExponentialRetryStrategy{
+		MaxRetries: maxRetries,
+		Delay:      delay,
+		Multiplier: multiplier,
+		MaxDelay:   maxDelay,
+		Jitter:     jitter,
+	}
+}
+
+func IsJitter(jitter string) bool {
+	switch jitter {
+	case "", JitterNone, JitterFull, JitterEqual, JitterDecorrelated:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *ExponentialRetryStrategy) ShouldRetry(retryCount uint) (time.Duration, bool) {
+	if retryCount >= s.MaxRetries {
+		return 0, false
+	}
+
+	if s.Jitter == JitterDecorrelated {
+		return s.ShouldRetryAfter(retryCount, 0)
+	}
+
+	ceiling := s.ceiling(retryCount)
+
+	switch s.Jitter {
+	case JitterFull:
+		return randomBetween(0, ceiling), true
+	case JitterEqual:
+		return randomBetween(ceiling/2, ceiling), true
+	default:
+		return ceiling, true
+	}
+}
+
+// ShouldRetryAfter applies decorrelated jitter, min(max_delay, random(delay, previous*3)),
+// starting from the base delay. The other jitter modes ignore the previous delay.
+func (s *ExponentialRetryStrategy) ShouldRetryAfter(retryCount uint, previous time.Duration) (time.Duration, bool) {
+	if s.Jitter != JitterDecorrelated {
+		return s.ShouldRetry(retryCount)
+	}
+
+	if retryCount >= s.MaxRetries {
+		return 0, false
+	}
+
+	highest := time.Duration(math.MaxInt64)
+	if previous = max(previous, s.Delay); previous < math.MaxInt64/3 {
+		highest = previous * 3
+	}
+
+	delay := randomBetween(s.Delay, highest)
+	if s.MaxDelay > 0 {
+		delay = min(delay, s.MaxDelay)
+	}
+
+	return delay, true
+}
+
+func (s *ExponentialRetryStrategy) growth() float64 {
+	if s.Multiplier <= 0 {
+		return defaultGrowth
+	}
+
+	return s.Multiplier
+}
+
+func (s *ExponentialRetryStrategy) ceiling(retryCount uint) time.Duration {
+	delay := float64(s.Delay) * math.Pow(s.growth(), float64(retryCount))
+	if s.MaxDelay > 0 && delay > float64(s.MaxDelay) {
+		return s.MaxDelay
+	}
+
+	if delay >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+
+	return time.Duration(delay)
+}
+
+func randomBetween(lowest, highest time.Duration) time.Duration {
+	if highest <= lowest {
+		return lowest
+	}
+
+	return lowest + rand.N(highest-lowest) //nolint:gosec // jitter does not need a cryptographic source
+}
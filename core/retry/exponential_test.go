@@ -0,0 +1,115 @@
+package retry_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/retry"
+)
+
+func TestExponentialRetryStrategy_ShouldRetry(t *testing.T) {
+	t.Run("without jitter grows exponentially", func(t *testing.T) {
+		strategy := retry.NewExponentialRetryStrategy(5, 100*time.Millisecond, 2, time.Second, "")
+
+		delay, shouldRetry := strategy.ShouldRetry(0)
+		assert.True(t, shouldRetry)
+		assert.Equal(t, 100*time.Millisecond, delay)
+
+		delay, _ = strategy.ShouldRetry(2)
+		assert.Equal(t, 400*time.Millisecond, delay)
+
+		delay, _ = strategy.ShouldRetry(4)
+		assert.Equal(t, time.Second, delay)
+	})
+
+	t.Run("full jitter stays within exponential ceiling", func(t *testing.T) {
+		strategy := retry.NewExponentialRetryStrategy(10, 100*time.Millisecond, 2, time.Second, retry.JitterFull)
+
+		for range 100 {
+			delay, shouldRetry := strategy.ShouldRetry(2)
+			assert.True(t, shouldRetry)
+			assert.GreaterOrEqual(t, delay, time.Duration(0))
+			assert.LessOrEqual(t, delay, 400*time.Millisecond)
+		}
+	})
+
+	t.Run("equal jitter keeps at least half of the exponential ceiling", func(t *testing.T) {
+		strategy := retry.NewExponentialRetryStrategy(10, 100*time.Millisecond, 2, time.Second, retry.JitterEqual)
+
+		for range 100 {
+			delay, shouldRetry := strategy.ShouldRetry(2)
+			assert.True(t, shouldRetry)
+			assert.GreaterOrEqual(t, delay, 200*time.Millisecond)
+			assert.LessOrEqual(t, delay, 400*time.Millisecond)
+		}
+	})
+
+	t.Run("decorrelated jitter grows from the previous delay", func(t *testing.T) {
+		strategy := retry.NewExponentialRetryStrategy(10, 100*time.Millisecond, 2, time.Second, retry.JitterDecorrelated)
+		decorrelated, ok := strategy.(api.PreviousDelayRetryStrategy)
+		require.True(t, ok)
+
+		for range 100 {
+			delay, shouldRetry := decorrelated.ShouldRetryAfter(1, 200*time.Millisecond)
+			assert.True(t, shouldRetry)
+			assert.GreaterOrEqual(t, delay, 100*time.Millisecond)
+			assert.LessOrEqual(t, delay, 600*time.Millisecond)
+
+			delay, _ = decorrelated.ShouldRetryAfter(1, 0)
+			assert.GreaterOrEqual(t, delay, 100*time.Millisecond)
+			assert.LessOrEqual(t, delay, 300*time.Millisecond)
+
+			delay, _ = decorrelated.ShouldRetryAfter(1, 900*time.Millisecond)
+			assert.LessOrEqual(t, delay, time.Second)
+		}
+
+		_, shouldRetry := decorrelated.ShouldRetryAfter(10, time.Second)
+		assert.False(t, shouldRetry)
+	})
+
+	t.Run("clamps overflowing delays without max delay", func(t *testing.T) {
+		for _, jitter := range []string{retry.JitterNone, retry.JitterFull, retry.JitterEqual, retry.JitterDecorrelated} {
+			strategy := retry.NewExponentialRetryStrategy(math.MaxUint, time.Second, 2, 0, jitter)
+
+			delay, shouldRetry := strategy.ShouldRetry(200)
+			assert.True(t, shouldRetry)
+			assert.GreaterOrEqual(t, delay, time.Duration(0), jitter)
+		}
+
+		delay, _ := retry.NewExponentialRetryStrategy(math.MaxUint, time.Second, 2, 0, "").ShouldRetry(200)
+		assert.Equal(t, time.Duration(math.MaxInt64), delay)
+	})
+
+	t.Run("jitter spreads delays", func(t *testing.T) {
+		strategy := retry.NewExponentialRetryStrategy(10, 100*time.Millisecond, 2, 10*time.Second, retry.JitterFull)
+
+		seen := make(map[time.Duration]bool)
+		for range 20 {
+			delay, _ := strategy.ShouldRetry(3)
+			seen[delay] = true
+		}
+
+		assert.Greater(t, len(seen), 1)
+	})
+
+	t.Run("stops after max retries", func(t *testing.T) {
+		strategy := retry.NewExponentialRetryStrategy(2, 100*time.Millisecond, 2, time.Second, retry.JitterFull)
+
+		delay, shouldRetry := strategy.ShouldRetry(2)
+		assert.False(t, shouldRetry)
+		assert.Equal(t, time.Duration(0), delay)
+	})
+}
+
+func TestIsJitter(t *testing.T) {
+	for _, jitter := range []string{"", retry.JitterNone, retry.JitterFull, retry.JitterEqual, retry.JitterDecorrelated} {
+		assert.True(t, retry.IsJitter(jitter), jitter)
+	}
+
+	assert.False(t, retry.IsJitter("random"))
+}
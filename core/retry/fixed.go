@@ -0,0 +1,23 @@
+package retry
+
+import "time"
+
+type FixedRetryStrategy struct {
+	MaxRetries uint
+	Delay      time.Duration
+}
+
+func NewFixedRetryStrategy(maxRetries uint, delay time.Duration) Strategy {
+	return &FixedRetryStrategy{
+		MaxRetries: maxRetries,
+		Delay:      delay,
+	}
+}
+
+func (s *FixedRetryStrategy) ShouldRetry(retryCount uint) (time.Duration, bool) {
+	if retryCount >= s.MaxRetries {
+		return 0, false
+	}
+
+	return s.Delay, true
+}
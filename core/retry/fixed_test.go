@@ -0,0 +1,30 @@
+package retry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gerfey/messenger/core/retry"
+)
+
+func TestFixedRetryStrategy_ShouldRetry(t *testing.T) {
+	t.Run("returns same delay for every attempt", func(t *testing.T) {
+		strategy := retry.NewFixedRetryStrategy(3, 250*time.Millisecond)
+
+		for attempt := range uint(3) {
+			delay, shouldRetry := strategy.ShouldRetry(attempt)
+			assert.True(t, shouldRetry)
+			assert.Equal(t, 250*time.Millisecond, delay)
+		}
+	})
+
+	t.Run("stops after max retries", func(t *testing.T) {
+		strategy := retry.NewFixedRetryStrategy(3, 250*time.Millisecond)
+
+		delay, shouldRetry := strategy.ShouldRetry(3)
+		assert.False(t, shouldRetry)
+		assert.Equal(t, time.Duration(0), delay)
+	})
+}
@@ -0,0 +1,21 @@
+package retry
+
+import "time"
+
+type ScheduleRetryStrategy struct {
+	Schedule []time.Duration
+}
+
+func NewScheduleRetryStrategy(schedule []time.Duration) Strategy {
+	return &ScheduleRetryStrategy{
+		Schedule: schedule,
+	}
+}
+
+func (s *ScheduleRetryStrategy) ShouldRetry(retryCount uint) (time.Duration, bool) {
+	if retryCount >= uint(len(s.Schedule)) {
+		return 0, false
+	}
+
+	return s.Schedule[retryCount], true
+}
@@ -0,0 +1,34 @@
+package retry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gerfey/messenger/core/retry"
+)
+
+func TestScheduleRetryStrategy_ShouldRetry(t *testing.T) {
+	t.Run("follows schedule", func(t *testing.T) {
+		schedule := []time.Duration{time.Second, 10 * time.Second, time.Minute, 10 * time.Minute}
+		strategy := retry.NewScheduleRetryStrategy(schedule)
+
+		for attempt, expected := range schedule {
+			delay, shouldRetry := strategy.ShouldRetry(uint(attempt))
+			assert.True(t, shouldRetry)
+			assert.Equal(t, expected, delay)
+		}
+
+		delay, shouldRetry := strategy.ShouldRetry(uint(len(schedule)))
+		assert.False(t, shouldRetry)
+		assert.Equal(t, time.Duration(0), delay)
+	})
+
+	t.Run("empty schedule never retries", func(t *testing.T) {
+		strategy := retry.NewScheduleRetryStrategy(nil)
+
+		_, shouldRetry := strategy.ShouldRetry(0)
+		assert.False(t, shouldRetry)
+	})
+}
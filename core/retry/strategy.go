@@ -3,11 +3,11 @@ package retry
 import (
 	"math"
 	"time"
+
+	"github.com/gerfey/messenger/api"
 )
 
-type Strategy interface {
-	ShouldRetry(attempt uint) (time.Duration, bool)
-}
+type Strategy = api.RetryStrategy
 type MultiplierRetryStrategy struct {
 	MaxRetries uint
 	Delay      time.Duration
@@ -0,0 +1,80 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/stamps"
+)
+
+type outcome struct {
+	reply api.Envelope
+	err   error
+}
+
+type Pending struct {
+	mu      sync.Mutex
+	waiters map[string]chan outcome
+}
+
+func NewPending() *Pending {
+	return &Pending{
+		waiters: make(map[string]chan outcome),
+	}
+}
+
+func (p *Pending) Await(ctx context.Context, correlationID string, send func() error) (api.Envelope, error) {
+	outcomes := make(chan outcome, 1)
+
+	p.mu.Lock()
+	p.waiters[correlationID] = outcomes
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		delete(p.waiters, correlationID)
+		p.mu.Unlock()
+	}()
+
+	if err := send(); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case o := <-outcomes:
+		return o.reply, o.err
+	}
+}
+
+func (p *Pending) Resolve(reply api.Envelope) bool {
+	replyStamp, ok := envelope.LastStampOf[stamps.ReplyStamp](reply)
+	if !ok {
+		return false
+	}
+
+	return p.settle(replyStamp.CorrelationID, outcome{reply: reply})
+}
+
+// Reject fails the request waiting for correlationID, e.g. when its reply cannot be decoded.
+func (p *Pending) Reject(correlationID string, err error) bool {
+	return p.settle(correlationID, outcome{err: err})
+}
+
+func (p *Pending) settle(correlationID string, o outcome) bool {
+	p.mu.Lock()
+	outcomes, ok := p.waiters[correlationID]
+	delete(p.waiters, correlationID)
+	p.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	outcomes <- o
+
+	return true
+}
@@ -0,0 +1,78 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/rpc"
+	"github.com/gerfey/messenger/core/stamps"
+	"github.com/gerfey/messenger/tests/helpers"
+)
+
+func TestPending_Await(t *testing.T) {
+	t.Run("resolves matching reply", func(t *testing.T) {
+		pending := rpc.NewPending()
+		reply := envelope.NewEnvelope(&helpers.TestMessage{}).WithStamp(stamps.ReplyStamp{CorrelationID: "42"})
+
+		var resolved bool
+		got, err := pending.Await(t.Context(), "42", func() error {
+			resolved = pending.Resolve(reply)
+
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.True(t, resolved)
+		assert.Equal(t, reply, got)
+	})
+
+	t.Run("returns rejection error", func(t *testing.T) {
+		pending := rpc.NewPending()
+		decodeErr := errors.New("unknown message type")
+
+		got, err := pending.Await(t.Context(), "42", func() error {
+			assert.True(t, pending.Reject("42", decodeErr))
+
+			return nil
+		})
+
+		require.ErrorIs(t, err, decodeErr)
+		assert.Nil(t, got)
+	})
+
+	t.Run("ignores unknown correlation id", func(t *testing.T) {
+		pending := rpc.NewPending()
+
+		ok := pending.Resolve(envelope.NewEnvelope(&helpers.TestMessage{}).WithStamp(stamps.ReplyStamp{CorrelationID: "1"}))
+
+		assert.False(t, ok)
+	})
+
+	t.Run("returns send error", func(t *testing.T) {
+		pending := rpc.NewPending()
+		sendErr := errors.New("send failed")
+
+		_, err := pending.Await(t.Context(), "42", func() error { return sendErr })
+
+		require.ErrorIs(t, err, sendErr)
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		pending := rpc.NewPending()
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+
+		got, err := pending.Await(ctx, "42", func() error { return nil })
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Nil(t, got)
+		assert.False(t, pending.Resolve(envelope.NewEnvelope(&helpers.TestMessage{}).
+			WithStamp(stamps.ReplyStamp{CorrelationID: "42"})))
+	})
+}
@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/stamps"
+)
+
+var ErrRequestNotSupported = errors.New("no sender supports request/reply for the message")
+
+type EmptyResult struct{}
+
+type RemoteError struct {
+	Handler string
+	Message string
+}
+
+func (e *RemoteError) Error() string {
+	if e.Handler == "" {
+		return "remote handler failed: " + e.Message
+	}
+
+	return fmt.Sprintf("remote handler %s failed: %s", e.Handler, e.Message)
+}
+
+func NewReply(request api.Envelope, handled api.Envelope, handleErr error) api.Envelope {
+	requestStamp, _ := envelope.LastStampOf[stamps.RequestStamp](request)
+
+	replyStamp := stamps.ReplyStamp{CorrelationID: requestStamp.CorrelationID}
+
+	var result any = &EmptyResult{}
+
+	if handleErr != nil {
+		replyStamp.Error = handleErr.Error()
+	} else if handled != nil {
+		if handledStamp, ok := envelope.LastStampOf[stamps.HandledStamp](handled); ok {
+			replyStamp.Handler = handledStamp.Handler
+			if handledStamp.Result != nil {
+				result = handledStamp.Result
+			}
+		}
+	}
+
+	return envelope.NewEnvelope(result).WithStamp(replyStamp)
+}
+
+func Result(reply api.Envelope) (stamps.HandledStamp, error) {
+	if handledStamp, ok := envelope.LastStampOf[stamps.HandledStamp](reply); ok {
+		return handledStamp, nil
+	}
+
+	replyStamp, _ := envelope.LastStampOf[stamps.ReplyStamp](reply)
+	if replyStamp.Error != "" {
+		return stamps.HandledStamp{}, &RemoteError{Handler: replyStamp.Handler, Message: replyStamp.Error}
+	}
+
+	result := reply.Message()
+	if _, empty := result.(*EmptyResult); empty {
+		result = nil
+	}
+
+	return stamps.HandledStamp{
+		Handler:    replyStamp.Handler,
+		Result:     result,
+		ResultType: reflect.TypeOf(result),
+	}, nil
+}
@@ -0,0 +1,90 @@
+package rpc_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/rpc"
+	"github.com/gerfey/messenger/core/stamps"
+	"github.com/gerfey/messenger/tests/helpers"
+)
+
+func TestNewReply(t *testing.T) {
+	request := envelope.NewEnvelope(&helpers.TestMessage{}).
+		WithStamp(stamps.RequestStamp{CorrelationID: "42", ReplyTo: "replies"})
+
+	t.Run("handler result", func(t *testing.T) {
+		handled := request.WithStamp(stamps.HandledStamp{Handler: "handler", Result: &helpers.TestMessage{ID: "1"}})
+
+		reply := rpc.NewReply(request, handled, nil)
+
+		assert.Equal(t, &helpers.TestMessage{ID: "1"}, reply.Message())
+		replyStamp, ok := envelope.LastStampOf[stamps.ReplyStamp](reply)
+		require.True(t, ok)
+		assert.Equal(t, stamps.ReplyStamp{CorrelationID: "42", Handler: "handler"}, replyStamp)
+	})
+
+	t.Run("handler without result", func(t *testing.T) {
+		handled := request.WithStamp(stamps.HandledStamp{Handler: "handler"})
+
+		reply := rpc.NewReply(request, handled, nil)
+
+		assert.Equal(t, &rpc.EmptyResult{}, reply.Message())
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		reply := rpc.NewReply(request, nil, errors.New("boom"))
+
+		replyStamp, _ := envelope.LastStampOf[stamps.ReplyStamp](reply)
+		assert.Equal(t, "boom", replyStamp.Error)
+		assert.Equal(t, &rpc.EmptyResult{}, reply.Message())
+	})
+}
+
+func TestResult(t *testing.T) {
+	t.Run("handled stamp of a synchronous reply", func(t *testing.T) {
+		stamp := stamps.HandledStamp{Handler: "handler", Result: "ok"}
+
+		result, err := rpc.Result(envelope.NewEnvelope(&helpers.TestMessage{}).WithStamp(stamp))
+
+		require.NoError(t, err)
+		assert.Equal(t, stamp, result)
+	})
+
+	t.Run("remote result", func(t *testing.T) {
+		reply := envelope.NewEnvelope(&helpers.TestMessage{ID: "1"}).
+			WithStamp(stamps.ReplyStamp{CorrelationID: "42", Handler: "handler"})
+
+		result, err := rpc.Result(reply)
+
+		require.NoError(t, err)
+		assert.Equal(t, "handler", result.Handler)
+		assert.Equal(t, &helpers.TestMessage{ID: "1"}, result.Result)
+		assert.Equal(t, reflect.TypeOf(&helpers.TestMessage{}), result.ResultType)
+	})
+
+	t.Run("empty remote result", func(t *testing.T) {
+		reply := envelope.NewEnvelope(&rpc.EmptyResult{}).WithStamp(stamps.ReplyStamp{CorrelationID: "42"})
+
+		result, err := rpc.Result(reply)
+
+		require.NoError(t, err)
+		assert.Nil(t, result.Result)
+	})
+
+	t.Run("remote error", func(t *testing.T) {
+		reply := envelope.NewEnvelope(&rpc.EmptyResult{}).
+			WithStamp(stamps.ReplyStamp{CorrelationID: "42", Handler: "handler", Error: "boom"})
+
+		_, err := rpc.Result(reply)
+
+		var remoteErr *rpc.RemoteError
+		require.ErrorAs(t, err, &remoteErr)
+		assert.Equal(t, "remote handler handler failed: boom", err.Error())
+	})
+}
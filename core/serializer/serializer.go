@@ -66,12 +66,12 @@ func (s *Serializer) Unmarshal(body []byte, headers map[string]string) (api.Enve
 		return nil, err
 	}
 
-	msgPtr := reflect.New(msgType.Elem()).Interface()
-	if unmarshalErr := json.Unmarshal(body, msgPtr); unmarshalErr != nil {
-		return nil, unmarshalErr
+	msg, err := s.createMessageValue(msgType, body)
+	if err != nil {
+		return nil, err
 	}
 
-	env := envelope.NewEnvelope(msgPtr)
+	env := envelope.NewEnvelope(msg)
 
 	if rawStamps, stampsOk := headers["stamps"]; stampsOk {
 		env = s.processStamps(env, rawStamps)
@@ -80,6 +80,24 @@ func (s *Serializer) Unmarshal(body []byte, headers map[string]string) (api.Enve
 	return env, nil
 }
 
+func (s *Serializer) createMessageValue(t reflect.Type, body []byte) (any, error) {
+	if t.Kind() == reflect.Ptr {
+		msgPtr := reflect.New(t.Elem()).Interface()
+		if err := json.Unmarshal(body, msgPtr); err != nil {
+			return nil, err
+		}
+
+		return msgPtr, nil
+	}
+
+	msgPtr := reflect.New(t)
+	if err := json.Unmarshal(body, msgPtr.Interface()); err != nil {
+		return nil, err
+	}
+
+	return msgPtr.Elem().Interface(), nil
+}
+
 func (s *Serializer) processStamps(env api.Envelope, rawStamps string) api.Envelope {
 	var sStamps []config.SerializedStamp
 	if stampsUnmarshalErr := json.Unmarshal([]byte(rawStamps), &sStamps); stampsUnmarshalErr != nil {
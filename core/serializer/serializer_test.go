@@ -97,6 +97,20 @@ func TestSerializer_Unmarshal(t *testing.T) {
 		assert.Equal(t, "test message", msg.Content)
 	})
 
+	t.Run("unmarshal value message", func(t *testing.T) {
+		resolver.RegisterMessage(helpers.TestMessage{})
+
+		body := []byte(`{"ID":"123","Content":"test message"}`)
+		headers := map[string]string{
+			"type": "helpers.TestMessage",
+		}
+
+		env, err := s.Unmarshal(body, headers)
+
+		require.NoError(t, err)
+		assert.Equal(t, helpers.TestMessage{ID: "123", Content: "test message"}, env.Message())
+	})
+
 	t.Run("unmarshal error missing type header", func(t *testing.T) {
 		body := []byte(`{"ID":"123","Content":"test"}`)
 		headers := map[string]string{}
@@ -0,0 +1,7 @@
+package stamps
+
+import "github.com/gerfey/messenger/core/ack"
+
+type AckStamp struct {
+	Acknowledger *ack.Acknowledger `json:"-"`
+}
@@ -1,5 +1,15 @@
 package stamps
 
+import "time"
+
 type DelayStamp struct {
 	Milliseconds int
 }
+
+func NewDelayStamp(delay time.Duration) DelayStamp {
+	return DelayStamp{Milliseconds: int(delay.Milliseconds())}
+}
+
+func (s DelayStamp) Duration() time.Duration {
+	return time.Duration(s.Milliseconds) * time.Millisecond
+}
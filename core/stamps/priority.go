@@ -0,0 +1,5 @@
+package stamps
+
+type PriorityStamp struct {
+	Priority uint8
+}
@@ -0,0 +1,12 @@
+package stamps
+
+type RequestStamp struct {
+	CorrelationID string
+	ReplyTo       string
+}
+
+type ReplyStamp struct {
+	CorrelationID string
+	Handler       string
+	Error         string
+}
@@ -0,0 +1,5 @@
+package stamps
+
+type SentToFailureTransportStamp struct {
+	OriginalTransport string
+}
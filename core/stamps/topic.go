@@ -0,0 +1,5 @@
+package stamps
+
+type TopicStamp struct {
+	Topic string
+}
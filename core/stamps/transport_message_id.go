@@ -0,0 +1,6 @@
+package stamps
+
+type TransportMessageIDStamp struct {
+	ID        string
+	Transport string
+}
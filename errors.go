@@ -0,0 +1,65 @@
+package messenger
+
+import (
+	"fmt"
+	"time"
+)
+
+type UnrecoverableError struct {
+	Err error
+}
+
+func Unrecoverable(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &UnrecoverableError{Err: err}
+}
+
+func (e *UnrecoverableError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UnrecoverableError) Unwrap() error {
+	return e.Err
+}
+
+type RecoverableError struct {
+	Err   error
+	Delay time.Duration
+}
+
+// Recoverable retries the message forever with the retry strategy's delay.
+func Recoverable(err error) error {
+	return RecoverableAfter(err, 0)
+}
+
+// RecoverableAfter retries the message after delay even once the retry strategy is exhausted.
+func RecoverableAfter(err error, delay time.Duration) error {
+	if err == nil {
+		return nil
+	}
+
+	return &RecoverableError{Err: err, Delay: delay}
+}
+
+func (e *RecoverableError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RecoverableError) Unwrap() error {
+	return e.Err
+}
+
+type BatchError struct {
+	Failures map[int]error
+}
+
+func BatchFailures(failures map[int]error) error {
+	return &BatchError{Failures: failures}
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("batch failed for %d message(s)", len(e.Failures))
+}
@@ -0,0 +1,58 @@
+package messenger_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger"
+)
+
+func TestUnrecoverable(t *testing.T) {
+	cause := errors.New("invalid payload")
+	err := fmt.Errorf("handle: %w", messenger.Unrecoverable(cause))
+
+	var unrecoverable *messenger.UnrecoverableError
+	require.ErrorAs(t, err, &unrecoverable)
+	require.ErrorIs(t, err, cause)
+	assert.Equal(t, "handle: invalid payload", err.Error())
+}
+
+func TestRecoverableAfter(t *testing.T) {
+	cause := errors.New("rate limited")
+	err := fmt.Errorf("handle: %w", messenger.RecoverableAfter(cause, time.Minute))
+
+	var recoverable *messenger.RecoverableError
+	require.ErrorAs(t, err, &recoverable)
+	require.ErrorIs(t, err, cause)
+	assert.Equal(t, time.Minute, recoverable.Delay)
+
+	var unrecoverable *messenger.UnrecoverableError
+	assert.NotErrorAs(t, err, &unrecoverable)
+}
+
+func TestRecoverable(t *testing.T) {
+	var recoverable *messenger.RecoverableError
+	require.ErrorAs(t, messenger.Recoverable(errors.New("temporary")), &recoverable)
+	assert.Zero(t, recoverable.Delay)
+}
+
+func TestErrorClassification_Nil(t *testing.T) {
+	require.NoError(t, messenger.Unrecoverable(nil))
+	require.NoError(t, messenger.Recoverable(nil))
+	require.NoError(t, messenger.RecoverableAfter(nil, time.Minute))
+}
+
+func TestBatchFailures(t *testing.T) {
+	cause := errors.New("invalid row")
+	err := fmt.Errorf("insert: %w", messenger.BatchFailures(map[int]error{2: cause}))
+
+	var batchErr *messenger.BatchError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, cause, batchErr.Failures[2])
+	assert.Equal(t, "insert: batch failed for 1 message(s)", err.Error())
+}
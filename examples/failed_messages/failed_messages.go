@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/gerfey/messenger/command"
+	"github.com/gerfey/messenger/core/builder"
+	"github.com/gerfey/messenger/core/config"
+	"github.com/gerfey/messenger/examples/retry_messenger/handler"
+)
+
+// Usage: go run ./examples/failed_messages failed:list|failed:show <id>|failed:retry <id>...|failed:remove <id>...
+func main() {
+	ctx := context.Background()
+
+	log := slog.Default()
+
+	cfg, err := config.LoadConfig("./examples/failed_messages/messenger.yaml")
+	if err != nil {
+		log.Error("ERROR load config", "error", err)
+
+		return
+	}
+
+	b := builder.NewBuilder(cfg, log)
+
+	_ = b.RegisterHandler(&handler.ExampleHelloErrorHandler{})
+
+	messenger, err := b.Build()
+	if err != nil {
+		log.Error("failed to build messenger", "error", err)
+
+		return
+	}
+
+	store, err := messenger.GetFailedMessageStore()
+	if err != nil {
+		log.Error("failed to get failed message store", "error", err)
+
+		return
+	}
+
+	if runErr := command.NewFailedMessagesCommand(store, os.Stdout).Run(ctx, os.Args[1:]); runErr != nil {
+		log.Error("command failed", "error", runErr)
+
+		os.Exit(1)
+	}
+}
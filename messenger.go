@@ -2,6 +2,7 @@ package messenger
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/gerfey/messenger/api"
@@ -9,10 +10,11 @@ import (
 )
 
 type Messenger struct {
-	defaultBusName   string
-	busLocator       api.BusLocator
-	transportManager *transport.Manager
-	routing          api.Router
+	defaultBusName     string
+	busLocator         api.BusLocator
+	transportManager   *transport.Manager
+	routing            api.Router
+	failedMessageStore api.FailedMessageStore
 }
 
 func NewMessenger(
@@ -20,12 +22,14 @@ func NewMessenger(
 	manager *transport.Manager,
 	busLocator api.BusLocator,
 	routing api.Router,
+	failedMessageStore api.FailedMessageStore,
 ) api.Messenger {
 	return &Messenger{
-		defaultBusName:   defaultBusName,
-		busLocator:       busLocator,
-		transportManager: manager,
-		routing:          routing,
+		defaultBusName:     defaultBusName,
+		busLocator:         busLocator,
+		transportManager:   manager,
+		routing:            routing,
+		failedMessageStore: failedMessageStore,
 	}
 }
 
@@ -56,3 +60,11 @@ func (m *Messenger) GetBusWith(name string) (api.MessageBus, error) {
 
 	return bus, nil
 }
+
+func (m *Messenger) GetFailedMessageStore() (api.FailedMessageStore, error) {
+	if m.failedMessageStore == nil {
+		return nil, errors.New("failed message store is not available: no listable failure transport configured")
+	}
+
+	return m.failedMessageStore, nil
+}
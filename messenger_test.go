@@ -11,10 +11,13 @@ import (
 
 	"github.com/gerfey/messenger"
 
+	"github.com/gerfey/messenger/api"
 	"github.com/gerfey/messenger/core/bus"
+	"github.com/gerfey/messenger/core/failure"
 	"github.com/gerfey/messenger/tests/helpers"
 	"github.com/gerfey/messenger/tests/mocks"
 	"github.com/gerfey/messenger/transport"
+	"github.com/gerfey/messenger/transport/inmemory"
 )
 
 func TestNewMessenger(t *testing.T) {
@@ -27,7 +30,7 @@ func TestNewMessenger(t *testing.T) {
 		mockBusLocator := mocks.NewMockBusLocator(ctrl)
 		mockRouter := mocks.NewMockRouter(ctrl)
 
-		m := messenger.NewMessenger(defaultBusName, mockManager, mockBusLocator, mockRouter)
+		m := messenger.NewMessenger(defaultBusName, mockManager, mockBusLocator, mockRouter, nil)
 
 		require.NotNil(t, m)
 		assert.IsType(t, &messenger.Messenger{}, m)
@@ -45,7 +48,7 @@ func TestMessenger_GetDefaultBus(t *testing.T) {
 
 		mockBusLocator.EXPECT().Get(defaultBusName).Return(mockBus, true)
 
-		m := messenger.NewMessenger(defaultBusName, nil, mockBusLocator, nil)
+		m := messenger.NewMessenger(defaultBusName, nil, mockBusLocator, nil, nil)
 
 		result, err := m.GetDefaultBus()
 
@@ -59,7 +62,7 @@ func TestMessenger_GetDefaultBus(t *testing.T) {
 
 		mockBusLocator.EXPECT().Get(defaultBusName).Return(nil, false)
 
-		m := messenger.NewMessenger(defaultBusName, nil, mockBusLocator, nil)
+		m := messenger.NewMessenger(defaultBusName, nil, mockBusLocator, nil, nil)
 
 		result, err := m.GetDefaultBus()
 
@@ -73,7 +76,7 @@ func TestMessenger_GetDefaultBus(t *testing.T) {
 
 		mockBusLocator.EXPECT().Get("").Return(nil, false)
 
-		m := messenger.NewMessenger("", nil, mockBusLocator, nil)
+		m := messenger.NewMessenger("", nil, mockBusLocator, nil, nil)
 
 		result, err := m.GetDefaultBus()
 
@@ -94,7 +97,7 @@ func TestMessenger_GetBusWith(t *testing.T) {
 
 		mockBusLocator.EXPECT().Get(busName).Return(mockBus, true)
 
-		m := messenger.NewMessenger("default", nil, mockBusLocator, nil)
+		m := messenger.NewMessenger("default", nil, mockBusLocator, nil, nil)
 
 		result, err := m.GetBusWith(busName)
 
@@ -108,7 +111,7 @@ func TestMessenger_GetBusWith(t *testing.T) {
 
 		mockBusLocator.EXPECT().Get(busName).Return(nil, false)
 
-		m := messenger.NewMessenger("default", nil, mockBusLocator, nil)
+		m := messenger.NewMessenger("default", nil, mockBusLocator, nil, nil)
 
 		result, err := m.GetBusWith(busName)
 
@@ -122,7 +125,7 @@ func TestMessenger_GetBusWith(t *testing.T) {
 
 		mockBusLocator.EXPECT().Get("").Return(nil, false)
 
-		m := messenger.NewMessenger("default", nil, mockBusLocator, nil)
+		m := messenger.NewMessenger("default", nil, mockBusLocator, nil, nil)
 
 		result, err := m.GetBusWith("")
 
@@ -139,7 +142,7 @@ func TestMessenger_GetBusWith(t *testing.T) {
 		mockBusLocator.EXPECT().Get("bus1").Return(bus1, true)
 		mockBusLocator.EXPECT().Get("bus2").Return(bus2, true)
 
-		m := messenger.NewMessenger("default", nil, mockBusLocator, nil)
+		m := messenger.NewMessenger("default", nil, mockBusLocator, nil, nil)
 
 		result1, err1 := m.GetBusWith("bus1")
 		result2, err2 := m.GetBusWith("bus2")
@@ -163,7 +166,7 @@ func TestMessenger_Run(t *testing.T) {
 
 		mockRouter.EXPECT().GetUsedTransports().Return(usedTransports)
 
-		m := messenger.NewMessenger("default", mockManager, nil, mockRouter)
+		m := messenger.NewMessenger("default", mockManager, nil, mockRouter, nil)
 
 		ctx, cancel := context.WithCancel(t.Context())
 
@@ -191,7 +194,7 @@ func TestMessenger_Run(t *testing.T) {
 
 		mockRouter.EXPECT().GetUsedTransports().Return(usedTransports)
 
-		m := messenger.NewMessenger("default", mockManager, nil, mockRouter)
+		m := messenger.NewMessenger("default", mockManager, nil, mockRouter, nil)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
 		defer cancel()
@@ -208,7 +211,7 @@ func TestMessenger_Run(t *testing.T) {
 
 		mockRouter.EXPECT().GetUsedTransports().Return(usedTransports)
 
-		m := messenger.NewMessenger("default", mockManager, nil, mockRouter)
+		m := messenger.NewMessenger("default", mockManager, nil, mockRouter, nil)
 
 		ctx, cancel := context.WithCancel(t.Context())
 
@@ -231,7 +234,7 @@ func TestMessenger_Run(t *testing.T) {
 	t.Run("run messenger with nil router", func(t *testing.T) {
 		mockManager := transport.NewManager(nil, nil, nil)
 
-		m := messenger.NewMessenger("default", mockManager, nil, nil)
+		m := messenger.NewMessenger("default", mockManager, nil, nil, nil)
 
 		ctx, cancel := context.WithCancel(t.Context())
 		defer cancel()
@@ -260,7 +263,7 @@ func TestMessenger_Integration(t *testing.T) {
 		usedTransports := []string{"inmemory"}
 		mockRouter.EXPECT().GetUsedTransports().Return(usedTransports)
 
-		m := messenger.NewMessenger("default", mockManager, busLocator, mockRouter)
+		m := messenger.NewMessenger("default", mockManager, busLocator, mockRouter, nil)
 
 		defaultBusResult, err := m.GetDefaultBus()
 		require.NoError(t, err)
@@ -291,3 +294,26 @@ func TestMessenger_Integration(t *testing.T) {
 		}
 	})
 }
+
+func TestMessenger_GetFailedMessageStore(t *testing.T) {
+	t.Run("returns configured store", func(t *testing.T) {
+		failed := inmemory.NewTransport("failed").(api.ListableTransport)
+		store := failure.NewStore([]api.ListableTransport{failed}, map[string]api.Transport{})
+
+		m := messenger.NewMessenger("default", nil, nil, nil, store)
+
+		result, err := m.GetFailedMessageStore()
+
+		require.NoError(t, err)
+		assert.Equal(t, store, result)
+	})
+
+	t.Run("returns error without store", func(t *testing.T) {
+		m := messenger.NewMessenger("default", nil, nil, nil, nil)
+
+		result, err := m.GetFailedMessageStore()
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,54 @@
+package e2e_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/core/builder"
+	"github.com/gerfey/messenger/core/config"
+
+	testHelpers "github.com/gerfey/messenger/tests/helpers"
+)
+
+func TestE2E_Batch_FillsFromSequentialConsumer(t *testing.T) {
+	logger, _ := testHelpers.NewFakeLogger()
+
+	cfg, err := config.LoadConfig("../fixtures/configs/e2e.yaml")
+	require.NoError(t, err)
+
+	batchHandler := &testHelpers.TestBatchHandler{Size: 3, Timeout: time.Hour}
+
+	b := builder.NewBuilder(cfg, logger)
+	require.NoError(t, b.RegisterHandler(batchHandler))
+	b.RegisterMiddleware("debug", testHelpers.NewDebugMiddleware("debug", logger))
+
+	messenger, err := b.Build()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	go func() {
+		if runErr := messenger.Run(ctx); runErr != nil && !errors.Is(runErr, context.Canceled) {
+			t.Logf("Messenger run error: %v", runErr)
+		}
+	}()
+
+	bus, err := messenger.GetDefaultBus()
+	require.NoError(t, err)
+
+	for _, content := range []string{"a", "b", "c"} {
+		_, err = bus.Dispatch(t.Context(), &testHelpers.TestMessage{Content: content})
+		require.NoError(t, err)
+	}
+
+	require.Eventually(t, func() bool {
+		return len(batchHandler.Batches()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Len(t, batchHandler.Batches()[0], 3)
+}
@@ -0,0 +1,58 @@
+package e2e_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/core/builder"
+	"github.com/gerfey/messenger/core/config"
+	"github.com/gerfey/messenger/core/stamps"
+
+	"github.com/gerfey/messenger/tests/fixtures/handlers"
+
+	testHelpers "github.com/gerfey/messenger/tests/helpers"
+)
+
+func TestE2E_Delay_DispatchWithDelayStamp(t *testing.T) {
+	logger, _ := testHelpers.NewFakeLogger()
+
+	cfg, err := config.LoadConfig("../fixtures/configs/e2e.yaml")
+	require.NoError(t, err)
+
+	b := builder.NewBuilder(cfg, logger)
+
+	testHandler := handlers.NewE2ETestHandler()
+	require.NoError(t, b.RegisterHandler(testHandler))
+
+	b.RegisterMiddleware("debug", testHelpers.NewDebugMiddleware("debug", logger))
+
+	messenger, err := b.Build()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	go func() {
+		if runErr := messenger.Run(ctx); runErr != nil && !errors.Is(runErr, context.Canceled) {
+			t.Logf("Messenger run error: %v", runErr)
+		}
+	}()
+
+	bus, err := messenger.GetDefaultBus()
+	require.NoError(t, err)
+
+	_, err = bus.Dispatch(t.Context(), &testHelpers.TestMessage{Content: "later"}, stamps.NewDelayStamp(200*time.Millisecond))
+	require.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int64(0), testHandler.GetCallCount())
+
+	assert.Eventually(t, func() bool {
+		return testHandler.GetCallCount() == 1
+	}, time.Second, 10*time.Millisecond)
+}
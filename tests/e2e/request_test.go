@@ -0,0 +1,68 @@
+package e2e_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/core/builder"
+	"github.com/gerfey/messenger/core/config"
+
+	testHelpers "github.com/gerfey/messenger/tests/helpers"
+)
+
+func TestE2E_Request_ReturnsRemoteHandlerResult(t *testing.T) {
+	logger, _ := testHelpers.NewFakeLogger()
+
+	cfg, err := config.LoadConfig("../fixtures/configs/e2e.yaml")
+	require.NoError(t, err)
+
+	b := builder.NewBuilder(cfg, logger)
+	require.NoError(t, b.RegisterHandler(&testHelpers.ValidHandlerWithResult{}))
+	b.RegisterMiddleware("debug", testHelpers.NewDebugMiddleware("debug", logger))
+
+	messenger, err := b.Build()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	go func() {
+		if runErr := messenger.Run(ctx); runErr != nil && !errors.Is(runErr, context.Canceled) {
+			t.Logf("Messenger run error: %v", runErr)
+		}
+	}()
+
+	bus, err := messenger.GetDefaultBus()
+	require.NoError(t, err)
+
+	result, err := bus.Request(t.Context(), &testHelpers.TestMessage{Content: "ping"}, time.Second)
+
+	require.NoError(t, err)
+	assert.Equal(t, &testHelpers.TestMessage{Content: "ping"}, result)
+}
+
+func TestE2E_Request_TimesOutWithoutWorker(t *testing.T) {
+	logger, _ := testHelpers.NewFakeLogger()
+
+	cfg, err := config.LoadConfig("../fixtures/configs/e2e.yaml")
+	require.NoError(t, err)
+
+	b := builder.NewBuilder(cfg, logger)
+	require.NoError(t, b.RegisterHandler(&testHelpers.ValidHandlerWithResult{}))
+	b.RegisterMiddleware("debug", testHelpers.NewDebugMiddleware("debug", logger))
+
+	messenger, err := b.Build()
+	require.NoError(t, err)
+
+	bus, err := messenger.GetDefaultBus()
+	require.NoError(t, err)
+
+	_, err = bus.Request(t.Context(), &testHelpers.TestMessage{Content: "ping"}, 50*time.Millisecond)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -1,6 +1,12 @@
 package helpers
 
-import "context"
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gerfey/messenger"
+)
 
 func TestEventListener(_ context.Context, _ *TestMessage) error {
 	return nil
@@ -116,3 +122,63 @@ func (h *ResultTestMessageHandler) Handle(_ context.Context, _ *TestMessage) (an
 
 	return h.Result, nil
 }
+
+type TestBatchHandler struct {
+	Size     int
+	Timeout  time.Duration
+	Failures map[string]error
+	Error    error
+	Panic    any
+	Block    bool
+
+	mu      sync.Mutex
+	batches [][]*TestMessage
+}
+
+func (h *TestBatchHandler) Handle(ctx context.Context, msgs []*TestMessage) error {
+	h.mu.Lock()
+	h.batches = append(h.batches, msgs)
+	h.mu.Unlock()
+
+	if h.Block {
+		<-ctx.Done()
+
+		return ctx.Err()
+	}
+
+	if h.Panic != nil {
+		panic(h.Panic)
+	}
+
+	if h.Error != nil {
+		return h.Error
+	}
+
+	failures := make(map[int]error)
+	for i, msg := range msgs {
+		if err, ok := h.Failures[msg.Content]; ok {
+			failures[i] = err
+		}
+	}
+
+	if len(failures) > 0 {
+		return messenger.BatchFailures(failures)
+	}
+
+	return nil
+}
+
+func (h *TestBatchHandler) BatchSize() int {
+	return h.Size
+}
+
+func (h *TestBatchHandler) BatchTimeout() time.Duration {
+	return h.Timeout
+}
+
+func (h *TestBatchHandler) Batches() [][]*TestMessage {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.batches
+}
@@ -0,0 +1,65 @@
+package helpers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/serializer"
+	"github.com/gerfey/messenger/core/stamps"
+)
+
+type typeResolver struct {
+	messageTypes map[string]reflect.Type
+	stampTypes   map[string]reflect.Type
+}
+
+// NewTestSerializer returns the default serializer knowing only the given message types
+// and the request/reply stamps, like a process that registered nothing else.
+func NewTestSerializer(messages ...any) api.Serializer {
+	resolver := &typeResolver{
+		messageTypes: make(map[string]reflect.Type),
+		stampTypes:   make(map[string]reflect.Type),
+	}
+
+	for _, msg := range messages {
+		resolver.RegisterMessage(msg)
+	}
+
+	resolver.RegisterStamp(stamps.RequestStamp{})
+	resolver.RegisterStamp(stamps.ReplyStamp{})
+
+	return serializer.NewSerializer(resolver)
+}
+
+func (r *typeResolver) Register(name string, t reflect.Type) {
+	r.messageTypes[name] = t
+}
+
+func (r *typeResolver) RegisterMessage(msg any) {
+	t := reflect.TypeOf(msg)
+	r.messageTypes[t.String()] = t
+}
+
+func (r *typeResolver) RegisterStamp(stamp any) {
+	t := reflect.TypeOf(stamp)
+	r.stampTypes[t.String()] = t
+}
+
+func (r *typeResolver) ResolveMessageType(name string) (reflect.Type, error) {
+	t, ok := r.messageTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown message type: %s", name)
+	}
+
+	return t, nil
+}
+
+func (r *typeResolver) ResolveStampType(name string) (reflect.Type, error) {
+	t, ok := r.stampTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown stamp type: %s", name)
+	}
+
+	return t, nil
+}
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	api "github.com/gerfey/messenger/api"
 	gomock "go.uber.org/mock/gomock"
@@ -61,6 +62,26 @@ func (mr *MockMessageBusMockRecorder) Dispatch(arg0, arg1 any, arg2 ...any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dispatch", reflect.TypeOf((*MockMessageBus)(nil).Dispatch), varargs...)
 }
 
+// Request mocks base method.
+func (m *MockMessageBus) Request(arg0 context.Context, arg1 any, arg2 time.Duration, arg3 ...api.Stamp) (any, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Request", varargs...)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Request indicates an expected call of Request.
+func (mr *MockMessageBusMockRecorder) Request(arg0, arg1, arg2 any, arg3 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*MockMessageBus)(nil).Request), varargs...)
+}
+
 // MockBusLocator is a mock of BusLocator interface.
 type MockBusLocator struct {
 	ctrl     *gomock.Controller
@@ -149,6 +149,139 @@ func (mr *MockSenderMockRecorder) Send(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockSender)(nil).Send), arg0, arg1)
 }
 
+// MockRequestSender is a mock of RequestSender interface.
+type MockRequestSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockRequestSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockRequestSenderMockRecorder is the mock recorder for MockRequestSender.
+type MockRequestSenderMockRecorder struct {
+	mock *MockRequestSender
+}
+
+// NewMockRequestSender creates a new mock instance.
+func NewMockRequestSender(ctrl *gomock.Controller) *MockRequestSender {
+	mock := &MockRequestSender{ctrl: ctrl}
+	mock.recorder = &MockRequestSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRequestSender) EXPECT() *MockRequestSenderMockRecorder {
+	return m.recorder
+}
+
+// Name mocks base method.
+func (m *MockRequestSender) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockRequestSenderMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockRequestSender)(nil).Name))
+}
+
+// Request mocks base method.
+func (m *MockRequestSender) Request(arg0 context.Context, arg1 api.Envelope) (api.Envelope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Request", arg0, arg1)
+	ret0, _ := ret[0].(api.Envelope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Request indicates an expected call of Request.
+func (mr *MockRequestSenderMockRecorder) Request(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*MockRequestSender)(nil).Request), arg0, arg1)
+}
+
+// Send mocks base method.
+func (m *MockRequestSender) Send(arg0 context.Context, arg1 api.Envelope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockRequestSenderMockRecorder) Send(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockRequestSender)(nil).Send), arg0, arg1)
+}
+
+// MockReplySender is a mock of ReplySender interface.
+type MockReplySender struct {
+	ctrl     *gomock.Controller
+	recorder *MockReplySenderMockRecorder
+	isgomock struct{}
+}
+
+// MockReplySenderMockRecorder is the mock recorder for MockReplySender.
+type MockReplySenderMockRecorder struct {
+	mock *MockReplySender
+}
+
+// NewMockReplySender creates a new mock instance.
+func NewMockReplySender(ctrl *gomock.Controller) *MockReplySender {
+	mock := &MockReplySender{ctrl: ctrl}
+	mock.recorder = &MockReplySenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReplySender) EXPECT() *MockReplySenderMockRecorder {
+	return m.recorder
+}
+
+// Name mocks base method.
+func (m *MockReplySender) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockReplySenderMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockReplySender)(nil).Name))
+}
+
+// Reply mocks base method.
+func (m *MockReplySender) Reply(ctx context.Context, request, reply api.Envelope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reply", ctx, request, reply)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reply indicates an expected call of Reply.
+func (mr *MockReplySenderMockRecorder) Reply(ctx, request, reply any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reply", reflect.TypeOf((*MockReplySender)(nil).Reply), ctx, request, reply)
+}
+
+// Send mocks base method.
+func (m *MockReplySender) Send(arg0 context.Context, arg1 api.Envelope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockReplySenderMockRecorder) Send(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockReplySender)(nil).Send), arg0, arg1)
+}
+
 // MockReceiver is a mock of Receiver interface.
 type MockReceiver struct {
 	ctrl     *gomock.Controller
@@ -277,6 +410,87 @@ func (mr *MockProducerMockRecorder) Send(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockProducer)(nil).Send), arg0, arg1)
 }
 
+// MockRequestReplyProducer is a mock of RequestReplyProducer interface.
+type MockRequestReplyProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockRequestReplyProducerMockRecorder
+	isgomock struct{}
+}
+
+// MockRequestReplyProducerMockRecorder is the mock recorder for MockRequestReplyProducer.
+type MockRequestReplyProducerMockRecorder struct {
+	mock *MockRequestReplyProducer
+}
+
+// NewMockRequestReplyProducer creates a new mock instance.
+func NewMockRequestReplyProducer(ctrl *gomock.Controller) *MockRequestReplyProducer {
+	mock := &MockRequestReplyProducer{ctrl: ctrl}
+	mock.recorder = &MockRequestReplyProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRequestReplyProducer) EXPECT() *MockRequestReplyProducerMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockRequestReplyProducer) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRequestReplyProducerMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRequestReplyProducer)(nil).Close))
+}
+
+// Reply mocks base method.
+func (m *MockRequestReplyProducer) Reply(ctx context.Context, request, reply api.Envelope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reply", ctx, request, reply)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reply indicates an expected call of Reply.
+func (mr *MockRequestReplyProducerMockRecorder) Reply(ctx, request, reply any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reply", reflect.TypeOf((*MockRequestReplyProducer)(nil).Reply), ctx, request, reply)
+}
+
+// Request mocks base method.
+func (m *MockRequestReplyProducer) Request(arg0 context.Context, arg1 api.Envelope) (api.Envelope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Request", arg0, arg1)
+	ret0, _ := ret[0].(api.Envelope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Request indicates an expected call of Request.
+func (mr *MockRequestReplyProducerMockRecorder) Request(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*MockRequestReplyProducer)(nil).Request), arg0, arg1)
+}
+
+// Send mocks base method.
+func (m *MockRequestReplyProducer) Send(arg0 context.Context, arg1 api.Envelope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockRequestReplyProducerMockRecorder) Send(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockRequestReplyProducer)(nil).Send), arg0, arg1)
+}
+
 // MockConsumer is a mock of Consumer interface.
 type MockConsumer struct {
 	ctrl     *gomock.Controller
@@ -683,3 +897,41 @@ func (mr *MockRoutedMessageMockRecorder) RoutingKey() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoutingKey", reflect.TypeOf((*MockRoutedMessage)(nil).RoutingKey))
 }
+
+// MockPartitionedMessage is a mock of PartitionedMessage interface.
+type MockPartitionedMessage struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartitionedMessageMockRecorder
+	isgomock struct{}
+}
+
+// MockPartitionedMessageMockRecorder is the mock recorder for MockPartitionedMessage.
+type MockPartitionedMessageMockRecorder struct {
+	mock *MockPartitionedMessage
+}
+
+// NewMockPartitionedMessage creates a new mock instance.
+func NewMockPartitionedMessage(ctrl *gomock.Controller) *MockPartitionedMessage {
+	mock := &MockPartitionedMessage{ctrl: ctrl}
+	mock.recorder = &MockPartitionedMessageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartitionedMessage) EXPECT() *MockPartitionedMessageMockRecorder {
+	return m.recorder
+}
+
+// PartitionKey mocks base method.
+func (m *MockPartitionedMessage) PartitionKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PartitionKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PartitionKey indicates an expected call of PartitionKey.
+func (mr *MockPartitionedMessageMockRecorder) PartitionKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PartitionKey", reflect.TypeOf((*MockPartitionedMessage)(nil).PartitionKey))
+}
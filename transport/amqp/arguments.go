@@ -0,0 +1,34 @@
+package amqp
+
+import (
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func toTable(arguments map[string]any) amqp.Table {
+	if len(arguments) == 0 {
+		return nil
+	}
+
+	table := make(amqp.Table, len(arguments))
+	for key, value := range arguments {
+		table[key] = toFieldValue(value)
+	}
+
+	return table
+}
+
+func toFieldValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return toTable(v)
+	case []any:
+		values := make([]any, len(v))
+		for i, item := range v {
+			values[i] = toFieldValue(item)
+		}
+
+		return values
+	default:
+		return value
+	}
+}
@@ -0,0 +1,57 @@
+package amqp
+
+import (
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestToTable(t *testing.T) {
+	t.Run("empty arguments", func(t *testing.T) {
+		assert.Nil(t, toTable(nil))
+	})
+
+	t.Run("yaml arguments are valid amqp fields", func(t *testing.T) {
+		var queue Queue
+		require.NoError(t, yaml.Unmarshal([]byte(`
+arguments:
+  x-queue-type: quorum
+  x-max-priority: 10
+  x-overflow: reject-publish
+  x-custom:
+    nested: true
+    values: [1, "two"]
+`), &queue))
+
+		table := toTable(queue.Arguments)
+
+		require.NoError(t, table.Validate())
+		assert.Equal(t, "quorum", table["x-queue-type"])
+		assert.Equal(t, 10, table["x-max-priority"])
+		assert.Equal(t, amqp.Table{"nested": true, "values": []any{1, "two"}}, table["x-custom"])
+	})
+}
+
+func TestExchangeConfig_UnmarshalYAML(t *testing.T) {
+	var opts OptionsConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+exchanges:
+  - name: audit
+    type: fanout
+    bindings:
+      - source: events
+        binding_keys: ["order.#"]
+  - name: archive
+    durable: false
+`), &opts))
+
+	require.Len(t, opts.Exchanges, 2)
+	assert.Equal(t, "fanout", opts.Exchanges[0].Type)
+	assert.True(t, opts.Exchanges[0].Durable)
+	assert.Equal(t, []ExchangeBinding{{Source: "events", BindingKeys: []string{"order.#"}}}, opts.Exchanges[0].Bindings)
+	assert.Equal(t, "topic", opts.Exchanges[1].Type)
+	assert.False(t, opts.Exchanges[1].Durable)
+}
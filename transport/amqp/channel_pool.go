@@ -0,0 +1,155 @@
+package amqp
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	defaultPoolMaxSize = 20
+)
+
+var ErrChannelPoolClosed = errors.New("amqp channel pool is closed")
+
+type poolResource interface {
+	IsClosed() bool
+	Close() error
+}
+
+type publishChannel struct {
+	*amqp.Channel
+
+	returns chan amqp.Return
+	delays  delayDeclarations
+}
+
+type channelPool[T poolResource] struct {
+	open   func() (T, error)
+	idle   chan T
+	slots  chan struct{}
+	mu     sync.Mutex
+	closed bool
+}
+
+func newChannelPool[T poolResource](open func() (T, error), minSize, maxSize int) *channelPool[T] {
+	if maxSize <= 0 {
+		maxSize = defaultPoolMaxSize
+	}
+
+	p := &channelPool[T]{
+		open:  open,
+		idle:  make(chan T, maxSize),
+		slots: make(chan struct{}, maxSize),
+	}
+
+	for range min(max(minSize, 0), maxSize) {
+		p.slots <- struct{}{}
+
+		resource, err := open()
+		if err != nil {
+			<-p.slots
+
+			break
+		}
+
+		p.idle <- resource
+	}
+
+	return p
+}
+
+func (p *channelPool[T]) get(ctx context.Context) (T, error) {
+	var zero T
+
+	for {
+		if p.isClosed() {
+			return zero, ErrChannelPoolClosed
+		}
+
+		select {
+		case resource := <-p.idle:
+			if p.healthy(resource) {
+				return resource, nil
+			}
+
+			continue
+		default:
+		}
+
+		select {
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		case resource := <-p.idle:
+			if p.healthy(resource) {
+				return resource, nil
+			}
+		case p.slots <- struct{}{}:
+			resource, err := p.open()
+			if err != nil {
+				<-p.slots
+
+				return zero, err
+			}
+
+			return resource, nil
+		}
+	}
+}
+
+func (p *channelPool[T]) put(resource T, healthy bool) {
+	if !healthy || p.isClosed() {
+		p.discard(resource)
+
+		return
+	}
+
+	if !p.healthy(resource) {
+		return
+	}
+
+	p.idle <- resource
+}
+
+func (p *channelPool[T]) close() error {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+
+	var errs []error
+	for {
+		select {
+		case resource := <-p.idle:
+			if err := resource.Close(); err != nil {
+				errs = append(errs, err)
+			}
+			<-p.slots
+		default:
+			return errors.Join(errs...)
+		}
+	}
+}
+
+func (p *channelPool[T]) healthy(resource T) bool {
+	if resource.IsClosed() {
+		<-p.slots
+
+		return false
+	}
+
+	return true
+}
+
+func (p *channelPool[T]) discard(resource T) {
+	_ = resource.Close()
+	<-p.slots
+}
+
+func (p *channelPool[T]) isClosed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.closed
+}
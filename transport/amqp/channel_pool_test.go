@@ -0,0 +1,145 @@
+package amqp
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeChannel struct {
+	closed atomic.Bool
+}
+
+func (f *fakeChannel) IsClosed() bool {
+	return f.closed.Load()
+}
+
+func (f *fakeChannel) Close() error {
+	f.closed.Store(true)
+
+	return nil
+}
+
+func newFakeOpener() (func() (*fakeChannel, error), *atomic.Int32) {
+	var opened atomic.Int32
+
+	return func() (*fakeChannel, error) {
+		opened.Add(1)
+
+		return &fakeChannel{}, nil
+	}, &opened
+}
+
+func TestChannelPool_WarmsUpMinSize(t *testing.T) {
+	open, opened := newFakeOpener()
+
+	newChannelPool(open, 3, 5)
+
+	assert.Equal(t, int32(3), opened.Load())
+}
+
+func TestChannelPool_ReusesChannels(t *testing.T) {
+	open, opened := newFakeOpener()
+	pool := newChannelPool(open, 1, 5)
+
+	first, err := pool.get(t.Context())
+	require.NoError(t, err)
+	pool.put(first, true)
+
+	second, err := pool.get(t.Context())
+	require.NoError(t, err)
+
+	assert.Same(t, first, second)
+	assert.Equal(t, int32(1), opened.Load())
+}
+
+func TestChannelPool_ReplacesClosedChannels(t *testing.T) {
+	open, opened := newFakeOpener()
+	pool := newChannelPool(open, 1, 1)
+
+	ch, err := pool.get(t.Context())
+	require.NoError(t, err)
+	_ = ch.Close()
+	pool.put(ch, true)
+
+	replacement, err := pool.get(t.Context())
+	require.NoError(t, err)
+
+	assert.NotSame(t, ch, replacement)
+	assert.False(t, replacement.IsClosed())
+	assert.Equal(t, int32(2), opened.Load())
+}
+
+func TestChannelPool_DiscardsUnhealthyChannels(t *testing.T) {
+	open, _ := newFakeOpener()
+	pool := newChannelPool(open, 0, 1)
+
+	ch, err := pool.get(t.Context())
+	require.NoError(t, err)
+	pool.put(ch, false)
+
+	assert.True(t, ch.IsClosed())
+
+	replacement, err := pool.get(t.Context())
+	require.NoError(t, err)
+	assert.NotSame(t, ch, replacement)
+}
+
+func TestChannelPool_BoundedByMaxSize(t *testing.T) {
+	open, _ := newFakeOpener()
+	pool := newChannelPool(open, 0, 1)
+
+	ch, err := pool.get(t.Context())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = pool.get(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	pool.put(ch, true)
+
+	again, err := pool.get(t.Context())
+	require.NoError(t, err)
+	assert.Same(t, ch, again)
+}
+
+func TestChannelPool_OpenFailureReleasesSlot(t *testing.T) {
+	failing := true
+	pool := newChannelPool(func() (*fakeChannel, error) {
+		if failing {
+			return nil, errors.New("channel limit reached")
+		}
+
+		return &fakeChannel{}, nil
+	}, 0, 1)
+
+	_, err := pool.get(t.Context())
+	require.Error(t, err)
+
+	failing = false
+	_, err = pool.get(t.Context())
+	require.NoError(t, err)
+}
+
+func TestChannelPool_Close(t *testing.T) {
+	open, _ := newFakeOpener()
+	pool := newChannelPool(open, 2, 2)
+
+	ch, err := pool.get(t.Context())
+	require.NoError(t, err)
+
+	require.NoError(t, pool.close())
+
+	_, err = pool.get(t.Context())
+	require.ErrorIs(t, err, ErrChannelPoolClosed)
+
+	pool.put(ch, true)
+	assert.True(t, ch.IsClosed())
+}
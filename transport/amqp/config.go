@@ -1,5 +1,19 @@
 package amqp
 
+import (
+	"errors"
+	"time"
+
+	"github.com/creasty/defaults"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	minReconnectDelay            = 100 * time.Millisecond
+	defaultDelayExchangeName     = "delays"
+	defaultDelayQueueNamePattern = "delay_%exchange_name%_%routing_key%_%delay%"
+)
+
 type TransportConfig struct {
 	Name    string
 	DSN     string
@@ -7,10 +21,49 @@ type TransportConfig struct {
 }
 
 type OptionsConfig struct {
-	AutoSetup bool             `yaml:"auto_setup" default:"false"`
-	Pool      PoolConfig       `yaml:"pool"`
-	Exchange  ExchangeConfig   `yaml:"exchange"`
-	Queues    map[string]Queue `yaml:"queues"`
+	AutoSetup     bool             `yaml:"auto_setup"     default:"false"`
+	Pool          PoolConfig       `yaml:"pool"`
+	Exchange      ExchangeConfig   `yaml:"exchange"`
+	Exchanges     []ExchangeConfig `yaml:"exchanges"` // additional exchanges, declared and bound on setup
+	RoutingKey    RoutingKeyConfig `yaml:"routing_key"`
+	Queues        map[string]Queue `yaml:"queues"`
+	Reconnect     ReconnectConfig  `yaml:"reconnect"`
+	Confirm       bool             `yaml:"confirm"        default:"false"`
+	Mandatory     bool             `yaml:"mandatory"      default:"false"`
+	PrefetchCount int              `yaml:"prefetch_count" default:"0"`
+	PrefetchSize  int              `yaml:"prefetch_size"  default:"0"`
+	Delay         DelayConfig      `yaml:"delay"`
+}
+
+// validate rejects options the broker or the producer cannot honour, before any connection is made.
+func (o OptionsConfig) validate() error {
+	if o.PrefetchSize != 0 {
+		return errors.New("prefetch_size is not supported by RabbitMQ, leave it at 0")
+	}
+
+	return validateRoutingKey(o.RoutingKey)
+}
+
+type ReconnectConfig struct {
+	Enabled    bool          `yaml:"enabled"    default:"true"`
+	Delay      time.Duration `yaml:"delay"      default:"500ms"` // at least 100ms
+	Multiplier float64       `yaml:"multiplier" default:"2"`
+	MaxDelay   time.Duration `yaml:"max_delay"  default:"30s"`
+}
+
+func (c ReconnectConfig) delay() time.Duration {
+	return max(c.Delay, minReconnectDelay)
+}
+
+type DelayConfig struct {
+	ExchangeName     string          `yaml:"exchange_name"      default:"delays"`
+	QueueNamePattern string          `yaml:"queue_name_pattern" default:"delay_%exchange_name%_%routing_key%_%delay%"`
+	Buckets          []time.Duration `yaml:"buckets"` // delays round up to one of these, default 100ms to 1h
+}
+
+type RoutingKeyConfig struct {
+	Strategy string `yaml:"strategy" default:"type_name"` // type_name, short_name, constant
+	Value    string `yaml:"value"`                        // only for constant
 }
 
 type PoolConfig struct {
@@ -20,16 +73,48 @@ type PoolConfig struct {
 }
 
 type ExchangeConfig struct {
-	Name       string `yaml:"name"`
-	Type       string `yaml:"type"        default:"topic"` // topic, direct, fanout
-	Durable    bool   `yaml:"durable"     default:"true"`
-	AutoDelete bool   `yaml:"auto_delete" default:"false"`
-	Internal   bool   `yaml:"internal"    default:"false"`
+	Name       string            `yaml:"name"`
+	Type       string            `yaml:"type"        default:"topic"` // topic, direct, fanout, headers
+	Durable    bool              `yaml:"durable"     default:"true"`
+	AutoDelete bool              `yaml:"auto_delete" default:"false"`
+	Internal   bool              `yaml:"internal"    default:"false"`
+	Arguments  map[string]any    `yaml:"arguments"`
+	Bindings   []ExchangeBinding `yaml:"bindings"` // exchange-to-exchange bindings with this exchange as destination
+}
+
+func (c *ExchangeConfig) UnmarshalYAML(value *yaml.Node) error {
+	type plain ExchangeConfig
+
+	cfg := plain{}
+	if err := defaults.Set(&cfg); err != nil {
+		return err
+	}
+
+	if err := value.Decode(&cfg); err != nil {
+		return err
+	}
+
+	*c = ExchangeConfig(cfg)
+
+	return nil
+}
+
+type ExchangeBinding struct {
+	Source      string         `yaml:"source"`
+	BindingKeys []string       `yaml:"binding_keys"`
+	Arguments   map[string]any `yaml:"arguments"`
 }
 
 type Queue struct {
-	BindingKeys []string `yaml:"binding_keys"`
-	Durable     bool     `yaml:"durable"      default:"true"`
-	Exclusive   bool     `yaml:"exclusive"    default:"false"`
-	AutoDelete  bool     `yaml:"auto_delete"  default:"false"`
+	BindingKeys       []string       `yaml:"binding_keys"`
+	Durable           bool           `yaml:"durable"            default:"true"`
+	Exclusive         bool           `yaml:"exclusive"          default:"false"`
+	AutoDelete        bool           `yaml:"auto_delete"        default:"false"`
+	ConsumerTag       string         `yaml:"consumer_tag"`
+	ExclusiveConsumer bool           `yaml:"exclusive_consumer" default:"false"`
+	Workers           int            `yaml:"workers"`        // dedicated workers, 0 uses the shared pool
+	PrefetchCount     int            `yaml:"prefetch_count"` // overrides the transport prefetch_count
+	Exchange          string         `yaml:"exchange"`       // exchange to bind to, empty uses the main exchange
+	Arguments         map[string]any `yaml:"arguments"`      // x-queue-type, x-max-priority, x-max-length, x-overflow...
+	BindingArguments  map[string]any `yaml:"binding_arguments"`
 }
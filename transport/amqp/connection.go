@@ -1,20 +1,40 @@
 package amqp
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
 	"sync"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/event"
+	"github.com/gerfey/messenger/core/retry"
 )
 
+var ErrConnectionNotAvailable = errors.New("amqp connection is not available")
+
 type Connection struct {
-	dsn  string
-	conn *amqp.Connection
-	lock sync.Mutex
+	config          TransportConfig
+	eventDispatcher api.EventDispatcher
+	conn            *amqp.Connection
+	lock            sync.RWMutex
+	closed          bool
+	reconnecting    bool
+	recovered       chan struct{}
+	hooks           []func(context.Context) error
 }
 
-func NewConnection(dsn string) (ConnectionAMQP, error) {
-	conn := &Connection{dsn: dsn}
+func NewConnection(config TransportConfig, eventDispatcher api.EventDispatcher) (ConnectionAMQP, error) {
+	conn := &Connection{
+		config:          config,
+		eventDispatcher: eventDispatcher,
+		recovered:       make(chan struct{}),
+	}
+
 	err := conn.Connect()
 	if err != nil {
 		return nil, err
@@ -23,17 +43,27 @@ func NewConnection(dsn string) (ConnectionAMQP, error) {
 	return conn, nil
 }
 
+// Channel opens a channel on the current connection. Without reconnect a lost connection
+// is redialed on the spot; with reconnect the background redial owns it and Channel
+// returns ErrConnectionNotAvailable until the connection is restored.
 func (c *Connection) Channel() (*amqp.Channel, error) {
-	c.lock.Lock()
-	defer c.lock.Unlock()
+	c.lock.RLock()
+	conn := c.conn
+	c.lock.RUnlock()
+
+	if conn == nil || conn.IsClosed() {
+		if c.config.Options.Reconnect.Enabled {
+			return nil, ErrConnectionNotAvailable
+		}
 
-	if c.conn == nil || c.conn.IsClosed() {
 		if err := c.Connect(); err != nil {
 			return nil, err
 		}
+
+		return c.Channel()
 	}
 
-	ch, err := c.conn.Channel()
+	ch, err := conn.Channel()
 	if err != nil {
 		return nil, fmt.Errorf("failed to open channel: %w", err)
 	}
@@ -42,23 +72,158 @@ func (c *Connection) Channel() (*amqp.Channel, error) {
 }
 
 func (c *Connection) Connect() error {
-	c.lock.Lock()
-	defer c.lock.Unlock()
-
-	conn, err := amqp.Dial(c.dsn)
+	conn, err := amqp.Dial(c.config.DSN)
 	if err != nil {
-		return fmt.Errorf("failed to connect to AMQP broker at '%s': %w", c.dsn, err)
+		return fmt.Errorf("failed to connect to AMQP broker at '%s': %w", c.config.DSN, err)
 	}
 
+	c.lock.Lock()
 	c.conn = conn
+	c.closed = false
+	c.lock.Unlock()
+
+	if c.config.Options.Reconnect.Enabled {
+		go c.watch(conn.NotifyClose(make(chan *amqp.Error, 1)))
+	}
 
 	return nil
 }
 
+func (c *Connection) OnReconnect(hook func(context.Context) error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.hooks = append(c.hooks, hook)
+}
+
+func (c *Connection) Recovered() <-chan struct{} {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.recovered
+}
+
 func (c *Connection) Close() error {
-	return c.conn.Close()
+	c.lock.Lock()
+	c.closed = true
+	conn := c.conn
+	c.lock.Unlock()
+
+	if conn == nil || conn.IsClosed() {
+		return nil
+	}
+
+	return conn.Close()
 }
 
 func (c *Connection) IsConnect() bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
 	return c.conn != nil && !c.conn.IsClosed()
 }
+
+func (c *Connection) watch(closeCh chan *amqp.Error) {
+	amqpErr, ok := <-closeCh
+	if !ok || amqpErr == nil {
+		return
+	}
+
+	c.lock.Lock()
+	if c.closed || c.reconnecting {
+		c.lock.Unlock()
+
+		return
+	}
+	c.reconnecting = true
+	c.lock.Unlock()
+
+	ctx := context.Background()
+	c.dispatch(ctx, event.TransportConnectionLostEvent{
+		Ctx:           ctx,
+		TransportName: c.config.Name,
+		Error:         amqpErr,
+	})
+
+	c.reconnect(ctx, time.Now())
+}
+
+func (c *Connection) reconnect(ctx context.Context, lostAt time.Time) {
+	cfg := c.config.Options.Reconnect
+	backoff := retry.NewExponentialRetryStrategy(math.MaxUint, cfg.delay(), cfg.Multiplier, cfg.MaxDelay, "")
+
+	for attempt := uint(0); ; attempt++ {
+		delay, _ := backoff.ShouldRetry(attempt)
+		time.Sleep(max(delay, cfg.delay()))
+
+		if c.isClosed() {
+			c.lock.Lock()
+			c.reconnecting = false
+			c.lock.Unlock()
+
+			return
+		}
+
+		if err := c.Connect(); err != nil {
+			continue
+		}
+
+		if err := c.runHooks(ctx); err != nil {
+			c.lock.RLock()
+			conn := c.conn
+			c.lock.RUnlock()
+			_ = conn.Close()
+
+			continue
+		}
+
+		c.lock.Lock()
+		if c.conn.IsClosed() {
+			c.lock.Unlock()
+
+			continue
+		}
+		c.reconnecting = false
+		close(c.recovered)
+		c.recovered = make(chan struct{})
+		c.lock.Unlock()
+
+		c.dispatch(ctx, event.TransportConnectionRestoredEvent{
+			Ctx:           ctx,
+			TransportName: c.config.Name,
+			Attempts:      attempt + 1,
+			Downtime:      time.Since(lostAt),
+		})
+
+		return
+	}
+}
+
+func (c *Connection) runHooks(ctx context.Context) error {
+	c.lock.RLock()
+	hooks := c.hooks
+	c.lock.RUnlock()
+
+	for _, hook := range hooks {
+		if err := hook(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Connection) isClosed() bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.closed
+}
+
+func (c *Connection) dispatch(ctx context.Context, evt any) {
+	if c.eventDispatcher == nil {
+		return
+	}
+
+	_ = c.eventDispatcher.Dispatch(ctx, evt)
+}
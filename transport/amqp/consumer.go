@@ -2,13 +2,14 @@ package amqp
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 
 	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/ack"
 	"github.com/gerfey/messenger/core/stamps"
 )
 
@@ -33,47 +34,80 @@ func NewConsumer(config TransportConfig, connection ConnectionAMQP, serializer a
 
 func (c *Consumer) Consume(ctx context.Context, handler func(context.Context, api.Envelope) error) error {
 	if !c.connection.IsConnect() {
-		return errors.New("amqp connection is not available")
+		return ErrConnectionNotAvailable
 	}
 
-	ch, err := c.connection.Channel()
-	if err != nil {
-		return fmt.Errorf("failed to create AMQP channel for consumer: %w", err)
-	}
+	queues := c.startWorkerPools(ctx, handler)
+
 	defer func() {
-		_ = ch.Close()
+		closed := make(map[chan job]struct{}, len(queues))
+		for _, jobs := range queues {
+			if _, ok := closed[jobs]; !ok {
+				close(jobs)
+				closed[jobs] = struct{}{}
+			}
+		}
+		c.wg.Wait()
 	}()
 
-	jobs := make(chan job)
-	c.startWorkerPool(ctx, jobs, handler)
-
-	err = c.startQueueConsumers(ctx, ch, jobs)
-	if err != nil {
-		return err
-	}
+	for {
+		err := c.consumeUntilClosed(ctx, queues)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 
-	<-ctx.Done()
-	close(jobs)
-	c.wg.Wait()
+		if !c.config.Options.Reconnect.Enabled {
+			return err
+		}
 
-	return ctx.Err()
+		if waitErr := c.waitForConnection(ctx); waitErr != nil {
+			return waitErr
+		}
+	}
 }
 
 func (c *Consumer) Close() error {
 	return nil
 }
 
-func (c *Consumer) startWorkerPool(
+func (c *Consumer) startWorkerPools(
 	ctx context.Context,
-	jobs chan job,
 	handler func(context.Context, api.Envelope) error,
-) {
+) map[string]chan job {
 	poolSize := c.config.Options.Pool.Size
 	if poolSize <= 0 {
 		poolSize = defaultPoolSize
 	}
 
-	for range poolSize {
+	queues := make(map[string]chan job, len(c.config.Options.Queues))
+
+	var shared chan job
+	for queueName, queueCfg := range c.config.Options.Queues {
+		if queueCfg.Workers > 0 {
+			jobs := make(chan job)
+			c.startWorkers(ctx, jobs, handler, queueCfg.Workers)
+			queues[queueName] = jobs
+
+			continue
+		}
+
+		if shared == nil {
+			shared = make(chan job)
+			c.startWorkers(ctx, shared, handler, poolSize)
+		}
+		queues[queueName] = shared
+	}
+
+	return queues
+}
+
+func (c *Consumer) startWorkers(
+	ctx context.Context,
+	jobs chan job,
+	handler func(context.Context, api.Envelope) error,
+	count int,
+) {
+	for range count {
 		c.wg.Add(1)
 		go c.startWorker(ctx, jobs, handler)
 	}
@@ -99,26 +133,143 @@ func (c *Consumer) startWorker(
 	}
 }
 
-func (c *Consumer) startQueueConsumers(ctx context.Context, ch *amqp.Channel, jobs chan job) error {
-	for queueName := range c.config.Options.Queues {
-		msgs, consumeErr := ch.ConsumeWithContext(
-			ctx,
-			queueName,
-			"",
-			false,
-			false,
-			false,
-			false,
-			nil,
-		)
-		if consumeErr != nil {
-			return fmt.Errorf("failed to start consuming from queue '%s': %w", queueName, consumeErr)
+func (c *Consumer) consumeUntilClosed(ctx context.Context, queues map[string]chan job) error {
+	var channels []*amqp.Channel
+	defer func() {
+		for _, ch := range channels {
+			_ = ch.Close()
 		}
+	}()
+
+	var forwarders sync.WaitGroup
+	defer forwarders.Wait()
 
-		go c.processQueueMessages(ctx, jobs, msgs)
+	consumeCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	for queueName, jobs := range queues {
+		ch, err := c.startQueueConsumer(consumeCtx, cancel, &forwarders, queueName, jobs)
+		if err != nil {
+			return err
+		}
+		channels = append(channels, ch)
 	}
 
-	return nil
+	<-consumeCtx.Done()
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return context.Cause(consumeCtx)
+}
+
+func (c *Consumer) startQueueConsumer(
+	ctx context.Context,
+	cancel context.CancelCauseFunc,
+	forwarders *sync.WaitGroup,
+	queueName string,
+	jobs chan job,
+) (*amqp.Channel, error) {
+	queueCfg := c.config.Options.Queues[queueName]
+
+	ch, err := c.connection.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AMQP channel for queue '%s': %w", queueName, err)
+	}
+
+	prefetchCount, prefetchSize := c.prefetch(queueCfg)
+	if prefetchCount > 0 || prefetchSize > 0 {
+		if err = ch.Qos(prefetchCount, prefetchSize, false); err != nil {
+			_ = ch.Close()
+
+			return nil, fmt.Errorf("failed to set prefetch for queue '%s': %w", queueName, err)
+		}
+	}
+
+	closed := ch.NotifyClose(make(chan *amqp.Error, 1))
+	cancelled := ch.NotifyCancel(make(chan string, 1))
+
+	msgs, err := ch.ConsumeWithContext(
+		ctx,
+		queueName,
+		queueCfg.ConsumerTag,
+		false,
+		queueCfg.ExclusiveConsumer,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		_ = ch.Close()
+
+		return nil, fmt.Errorf("failed to start consuming from queue '%s': %w", queueName, err)
+	}
+
+	forwarders.Add(2)
+	go func() {
+		defer forwarders.Done()
+		c.processQueueMessages(ctx, jobs, msgs)
+	}()
+	go func() {
+		defer forwarders.Done()
+		watchQueueChannel(ctx, cancel, queueName, closed, cancelled)
+	}()
+
+	return ch, nil
+}
+
+func (c *Consumer) prefetch(queueCfg Queue) (int, int) {
+	prefetchCount := c.config.Options.PrefetchCount
+	if queueCfg.PrefetchCount > 0 {
+		prefetchCount = queueCfg.PrefetchCount
+	}
+
+	return prefetchCount, c.config.Options.PrefetchSize
+}
+
+func watchQueueChannel(
+	ctx context.Context,
+	cancel context.CancelCauseFunc,
+	queueName string,
+	closed <-chan *amqp.Error,
+	cancelled <-chan string,
+) {
+	select {
+	case <-ctx.Done():
+	case amqpErr := <-closed:
+		if amqpErr != nil {
+			cancel(amqpErr)
+
+			return
+		}
+
+		cancel(fmt.Errorf("amqp channel for queue '%s' closed", queueName))
+	case tag := <-cancelled:
+		cancel(fmt.Errorf("consumer '%s' of queue '%s' cancelled by broker", tag, queueName))
+	}
+}
+
+func (c *Consumer) waitForConnection(ctx context.Context) error {
+	for {
+		recovered := c.connection.Recovered()
+		if c.connection.IsConnect() {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-recovered:
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(c.config.Options.Reconnect.delay()):
+		return nil
+	}
 }
 
 func (c *Consumer) processQueueMessages(ctx context.Context, jobs chan job, messages <-chan amqp.Delivery) {
@@ -130,7 +281,12 @@ func (c *Consumer) processQueueMessages(ctx context.Context, jobs chan job, mess
 			if !ok {
 				return
 			}
-			jobs <- job{d: d}
+
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- job{d: d}:
+			}
 		}
 	}
 }
@@ -140,32 +296,31 @@ func (c *Consumer) handleDelivery(
 	d amqp.Delivery,
 	handler func(context.Context, api.Envelope) error,
 ) {
-	headersMap := map[string]string{}
-	for k, v := range d.Headers {
-		if s, ok := v.(string); ok {
-			headersMap[k] = s
-		}
-	}
-
-	env, err := c.serializer.Unmarshal(d.Body, headersMap)
+	env, err := c.serializer.Unmarshal(d.Body, stringHeaders(d.Headers))
 	if err != nil {
 		_ = d.Nack(false, false)
 
 		return
 	}
 
-	env = env.WithStamp(stamps.ReceivedStamp{
-		Transport: c.config.Name,
+	acknowledger := ack.New(func(err error) {
+		if err != nil {
+			_ = d.Nack(false, false)
+
+			return
+		}
+
+		_ = d.Ack(false)
 	})
 
-	err = handler(ctx, env)
-	if err != nil {
-		_ = d.Nack(false, false)
+	env = withReplyAddress(env, d).WithStamp(stamps.ReceivedStamp{
+		Transport: c.config.Name,
+	}).WithStamp(receivedStampOf(d)).WithStamp(stamps.AckStamp{Acknowledger: acknowledger})
 
-		return
+	err = handler(ctx, env)
+	if !acknowledger.Deferred() {
+		acknowledger.Ack(err)
 	}
-
-	_ = d.Ack(false)
 }
 
 type job struct {
@@ -0,0 +1,179 @@
+package amqp
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/creasty/defaults"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeConnection struct {
+	mu        sync.Mutex
+	connected bool
+	recovered chan struct{}
+}
+
+func newFakeConnection(connected bool) *fakeConnection {
+	return &fakeConnection{connected: connected, recovered: make(chan struct{})}
+}
+
+func (f *fakeConnection) Channel() (*amqp.Channel, error) {
+	return nil, ErrConnectionNotAvailable
+}
+
+func (f *fakeConnection) Connect() error {
+	return nil
+}
+
+func (f *fakeConnection) IsConnect() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.connected
+}
+
+func (f *fakeConnection) OnReconnect(func(context.Context) error) {}
+
+func (f *fakeConnection) Recovered() <-chan struct{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.recovered
+}
+
+func (f *fakeConnection) Close() error {
+	return nil
+}
+
+func (f *fakeConnection) recover() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.connected = true
+	close(f.recovered)
+	f.recovered = make(chan struct{})
+}
+
+func newReconnectingConsumer(connection ConnectionAMQP) *Consumer {
+	return &Consumer{
+		config: TransportConfig{
+			Name: "amqp",
+			Options: OptionsConfig{
+				Reconnect: ReconnectConfig{Enabled: true, Delay: time.Millisecond},
+			},
+		},
+		connection: connection,
+	}
+}
+
+func TestConsumer_Consume_WithoutConnection(t *testing.T) {
+	consumer := newReconnectingConsumer(newFakeConnection(false))
+
+	err := consumer.Consume(t.Context(), nil)
+
+	require.ErrorIs(t, err, ErrConnectionNotAvailable)
+}
+
+func TestConsumer_WaitForConnection(t *testing.T) {
+	t.Run("resumes after connection recovery", func(t *testing.T) {
+		connection := newFakeConnection(false)
+		consumer := newReconnectingConsumer(connection)
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			connection.recover()
+		}()
+
+		require.NoError(t, consumer.waitForConnection(t.Context()))
+		assert.True(t, connection.IsConnect())
+	})
+
+	t.Run("stops when context is cancelled", func(t *testing.T) {
+		consumer := newReconnectingConsumer(newFakeConnection(false))
+
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+
+		require.ErrorIs(t, consumer.waitForConnection(ctx), context.DeadlineExceeded)
+	})
+}
+
+func TestReconnectConfig(t *testing.T) {
+	t.Run("enabled by default", func(t *testing.T) {
+		var opts OptionsConfig
+		require.NoError(t, defaults.Set(&opts))
+
+		assert.True(t, opts.Reconnect.Enabled)
+		assert.Equal(t, 500*time.Millisecond, opts.Reconnect.delay())
+	})
+
+	t.Run("delay has a floor", func(t *testing.T) {
+		assert.Equal(t, minReconnectDelay, ReconnectConfig{}.delay())
+		assert.Equal(t, minReconnectDelay, ReconnectConfig{Delay: time.Millisecond}.delay())
+	})
+}
+
+func TestConsumer_StartWorkerPools(t *testing.T) {
+	consumer := &Consumer{
+		config: TransportConfig{
+			Options: OptionsConfig{
+				Pool: PoolConfig{Size: 2},
+				Queues: map[string]Queue{
+					"orders":  {},
+					"emails":  {},
+					"reports": {Workers: 1},
+				},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	queues := consumer.startWorkerPools(ctx, nil)
+
+	require.Len(t, queues, 3)
+	assert.Equal(t, queues["orders"], queues["emails"])
+	assert.NotEqual(t, queues["orders"], queues["reports"])
+
+	cancel()
+	consumer.wg.Wait()
+}
+
+func TestConsumer_Prefetch(t *testing.T) {
+	consumer := &Consumer{
+		config: TransportConfig{
+			Options: OptionsConfig{PrefetchCount: 10, PrefetchSize: 4096},
+		},
+	}
+
+	count, size := consumer.prefetch(Queue{})
+	assert.Equal(t, 10, count)
+	assert.Equal(t, 4096, size)
+
+	count, _ = consumer.prefetch(Queue{PrefetchCount: 1})
+	assert.Equal(t, 1, count)
+}
+
+func TestReceivedStampOf(t *testing.T) {
+	stamp := receivedStampOf(amqp.Delivery{
+		Exchange:      "orders",
+		RoutingKey:    "order.created",
+		Redelivered:   true,
+		DeliveryTag:   42,
+		CorrelationId: "c-1",
+		ReplyTo:       "amq.rabbitmq.reply-to.abc",
+	})
+
+	assert.Equal(t, AmqpReceivedStamp{
+		Exchange:      "orders",
+		RoutingKey:    "order.created",
+		Redelivered:   true,
+		DeliveryTag:   42,
+		CorrelationID: "c-1",
+		ReplyTo:       "amq.rabbitmq.reply-to.abc",
+	}, stamp)
+}
@@ -0,0 +1,162 @@
+package amqp
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	delayQueueExpiryMargin = 10 * time.Second
+)
+
+var defaultDelayBuckets = []time.Duration{
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	20 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	2 * time.Minute,
+	5 * time.Minute,
+	10 * time.Minute,
+	20 * time.Minute,
+	30 * time.Minute,
+	time.Hour,
+}
+
+// delayDeclarations remembers the delay exchange and queues a channel already declared.
+// A delay queue expires once unused for its delay plus delayQueueExpiryMargin, and
+// publishing does not count as use, so a queue is declared again after half the margin.
+type delayDeclarations struct {
+	exchange bool
+	queues   map[string]time.Time
+}
+
+func delayQueueName(pattern, exchange, routingKey string, delay time.Duration) string {
+	return strings.NewReplacer(
+		"%exchange_name%", exchange,
+		"%routing_key%", routingKey,
+		"%delay%", strconv.FormatInt(delay.Milliseconds(), 10),
+	).Replace(pattern)
+}
+
+// exchangeName and queueNamePattern fall back to the defaults for configs built in code,
+// which never pass through the yaml defaults.
+func (c DelayConfig) exchangeName() string {
+	if c.ExchangeName == "" {
+		return defaultDelayExchangeName
+	}
+
+	return c.ExchangeName
+}
+
+func (c DelayConfig) queueNamePattern() string {
+	if c.QueueNamePattern == "" {
+		return defaultDelayQueueNamePattern
+	}
+
+	return c.QueueNamePattern
+}
+
+func (c DelayConfig) buckets() []time.Duration {
+	buckets := make([]time.Duration, 0, len(c.Buckets))
+	for _, bucket := range c.Buckets {
+		if bucket >= time.Millisecond {
+			buckets = append(buckets, bucket.Truncate(time.Millisecond))
+		}
+	}
+
+	if len(buckets) == 0 {
+		return defaultDelayBuckets
+	}
+
+	slices.Sort(buckets)
+
+	return slices.Compact(buckets)
+}
+
+// bucket rounds a delay up to the nearest bucket, or to a multiple of the longest one,
+// so jittered delays share a few queues instead of getting one queue each.
+func (c DelayConfig) bucket(delay time.Duration) time.Duration {
+	buckets := c.buckets()
+
+	for _, bucket := range buckets {
+		if delay <= bucket {
+			return bucket
+		}
+	}
+
+	longest := buckets[len(buckets)-1]
+
+	return (delay + longest - 1) / longest * longest
+}
+
+func declareDelayExchange(ch *amqp.Channel, cfg DelayConfig) error {
+	err := ch.ExchangeDeclare(cfg.exchangeName(), amqp.ExchangeDirect, true, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to declare delay exchange '%s': %w", cfg.exchangeName(), err)
+	}
+
+	return nil
+}
+
+func declareDelayQueue(
+	ch *publishChannel,
+	cfg DelayConfig,
+	exchange string,
+	routingKey string,
+	delay time.Duration,
+) (string, error) {
+	if ch.delays.queues == nil {
+		ch.delays.queues = make(map[string]time.Time)
+	}
+
+	if !ch.delays.exchange {
+		if err := declareDelayExchange(ch.Channel, cfg); err != nil {
+			return "", err
+		}
+
+		ch.delays.exchange = true
+	}
+
+	delay = cfg.bucket(delay)
+	queueName := delayQueueName(cfg.queueNamePattern(), exchange, routingKey, delay)
+
+	if time.Now().Before(ch.delays.queues[queueName]) {
+		return queueName, nil
+	}
+
+	_, err := ch.QueueDeclare(
+		queueName,
+		true,
+		false,
+		false,
+		false,
+		amqp.Table{
+			"x-message-ttl":             delay.Milliseconds(),
+			"x-expires":                 (delay + delayQueueExpiryMargin).Milliseconds(),
+			"x-dead-letter-exchange":    exchange,
+			"x-dead-letter-routing-key": routingKey,
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to declare delay queue '%s': %w", queueName, err)
+	}
+
+	if err = ch.QueueBind(queueName, queueName, cfg.exchangeName(), false, nil); err != nil {
+		return "", fmt.Errorf("failed to bind delay queue '%s': %w", queueName, err)
+	}
+
+	ch.delays.queues[queueName] = time.Now().Add(delayQueueExpiryMargin / 2)
+
+	return queueName, nil
+}
@@ -0,0 +1,71 @@
+package amqp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelayQueueName(t *testing.T) {
+	t.Run("default pattern", func(t *testing.T) {
+		name := delayQueueName(defaultDelayQueueNamePattern, "orders", "order.created", 1500*time.Millisecond)
+
+		assert.Equal(t, "delay_orders_order.created_1500", name)
+	})
+
+	t.Run("custom pattern", func(t *testing.T) {
+		name := delayQueueName("%delay%ms.%exchange_name%", "orders", "order.created", time.Second)
+
+		assert.Equal(t, "1000ms.orders", name)
+	})
+}
+
+func TestDelayConfig_Defaults(t *testing.T) {
+	t.Run("empty config falls back to defaults", func(t *testing.T) {
+		cfg := DelayConfig{}
+
+		assert.Equal(t, "delays", cfg.exchangeName())
+		assert.Equal(t, "delay_orders_order.created_1000",
+			delayQueueName(cfg.queueNamePattern(), "orders", "order.created", time.Second))
+	})
+
+	t.Run("configured values win", func(t *testing.T) {
+		cfg := DelayConfig{ExchangeName: "later", QueueNamePattern: "%exchange_name%.%delay%"}
+
+		assert.Equal(t, "later", cfg.exchangeName())
+		assert.Equal(t, "%exchange_name%.%delay%", cfg.queueNamePattern())
+	})
+}
+
+func TestDelayConfig_Bucket(t *testing.T) {
+	t.Run("rounds up to the default buckets", func(t *testing.T) {
+		cfg := DelayConfig{}
+
+		assert.Equal(t, 100*time.Millisecond, cfg.bucket(time.Millisecond))
+		assert.Equal(t, time.Second, cfg.bucket(time.Second))
+		assert.Equal(t, 2*time.Second, cfg.bucket(1234*time.Millisecond))
+		assert.Equal(t, time.Minute, cfg.bucket(31*time.Second))
+		assert.Equal(t, 3*time.Hour, cfg.bucket(2*time.Hour+time.Millisecond))
+	})
+
+	t.Run("jittered delays share a queue", func(t *testing.T) {
+		cfg := DelayConfig{}
+		names := make(map[string]struct{})
+
+		for ms := 1001; ms <= 2000; ms += 7 {
+			delay := cfg.bucket(time.Duration(ms) * time.Millisecond)
+			names[delayQueueName(cfg.queueNamePattern(), "orders", "order.created", delay)] = struct{}{}
+		}
+
+		assert.Len(t, names, 1)
+	})
+
+	t.Run("configured buckets are sorted and deduplicated", func(t *testing.T) {
+		cfg := DelayConfig{Buckets: []time.Duration{time.Minute, time.Second, time.Minute, time.Microsecond}}
+
+		assert.Equal(t, []time.Duration{time.Second, time.Minute}, cfg.buckets())
+		assert.Equal(t, time.Minute, cfg.bucket(2*time.Second))
+		assert.Equal(t, 2*time.Minute, cfg.bucket(90*time.Second))
+	})
+}
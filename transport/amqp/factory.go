@@ -10,10 +10,14 @@ import (
 	"github.com/gerfey/messenger/api"
 )
 
-type TransportFactory struct{}
+type TransportFactory struct {
+	eventDispatcher api.EventDispatcher
+}
 
-func NewTransportFactory() api.TransportFactory {
-	return &TransportFactory{}
+func NewTransportFactory(eventDispatcher api.EventDispatcher) api.TransportFactory {
+	return &TransportFactory{
+		eventDispatcher: eventDispatcher,
+	}
 }
 
 func (f *TransportFactory) Supports(dsn string) bool {
@@ -35,11 +39,15 @@ func (f *TransportFactory) Create(
 		return nil, fmt.Errorf("unmarshal options: %w", err)
 	}
 
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
 	cfg := TransportConfig{
 		Name:    name,
 		DSN:     dsn,
 		Options: opts,
 	}
 
-	return NewTransport(cfg, serializer)
+	return NewTransport(cfg, serializer, f.eventDispatcher)
 }
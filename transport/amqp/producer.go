@@ -4,29 +4,51 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 
 	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/rpc"
+	"github.com/gerfey/messenger/core/stamps"
+)
+
+var (
+	ErrMessageReturned = errors.New("message returned by broker")
+	ErrMessageNotAcked = errors.New("message not acknowledged by broker")
 )
 
 type Producer struct {
 	config     TransportConfig
 	connection ConnectionAMQP
 	serializer api.Serializer
+	channels   *channelPool[*publishChannel]
+	pending    *rpc.Pending
+	replies    *publishChannel
+	requestMu  sync.Mutex
 }
 
-func NewProducer(config TransportConfig, connection ConnectionAMQP, serializer api.Serializer) (api.Producer, error) {
-	return &Producer{
+func NewProducer(
+	config TransportConfig,
+	connection ConnectionAMQP,
+	serializer api.Serializer,
+) (api.RequestReplyProducer, error) {
+	p := &Producer{
 		config:     config,
 		connection: connection,
 		serializer: serializer,
-	}, nil
+		pending:    rpc.NewPending(),
+	}
+
+	p.channels = newChannelPool(p.openChannel, config.Options.Pool.MinSize, config.Options.Pool.MaxSize)
+
+	return p, nil
 }
 
 func (p *Producer) Send(ctx context.Context, env api.Envelope) error {
 	if !p.connection.IsConnect() {
-		return errors.New("amqp connection is not available")
+		return ErrConnectionNotAvailable
 	}
 
 	body, headersMap, err := p.serializer.Marshal(env)
@@ -34,35 +56,51 @@ func (p *Producer) Send(ctx context.Context, env api.Envelope) error {
 		return fmt.Errorf("failed to marshal envelope: %w", err)
 	}
 
-	headers := amqp.Table{}
-	for k, v := range headersMap {
-		headers[k] = v
+	ch, err := p.channels.get(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire AMQP channel: %w", err)
 	}
 
-	ch, err := p.connection.Channel()
-	if err != nil {
-		return fmt.Errorf("failed to create AMQP channel: %w", err)
-	}
-	defer func() {
-		_ = ch.Close()
-	}()
-
-	routingKey := getRoutingKey(env.Message())
-
-	err = ch.PublishWithContext(ctx,
-		p.config.Options.Exchange.Name,
-		routingKey,
-		false,
-		false,
-		amqp.Publishing{
-			Headers:     headers,
-			ContentType: "application/json",
-			Body:        body,
-		})
+	err = p.sendOn(ctx, ch, env, newPublishing(env, body, headersMap))
+	p.channels.put(ch, err == nil || errors.Is(err, ErrMessageReturned))
+
+	return err
+}
+
+func (p *Producer) sendOn(ctx context.Context, ch *publishChannel, env api.Envelope, msg amqp.Publishing) error {
+	routingKey := p.routingKey(env)
+	exchange := p.config.Options.Exchange.Name
+
+	if amqpStamp, ok := envelope.LastStampOf[AmqpStamp](env); ok {
+		if amqpStamp.RoutingKey != "" {
+			routingKey = amqpStamp.RoutingKey
+		}
+
+		if amqpStamp.Exchange != "" {
+			exchange = amqpStamp.Exchange
+		}
+	}
+
+	if delayStamp, ok := envelope.LastStampOf[stamps.DelayStamp](env); ok && delayStamp.Milliseconds > 0 {
+		queueName, declareErr := declareDelayQueue(
+			ch,
+			p.config.Options.Delay,
+			exchange,
+			routingKey,
+			delayStamp.Duration(),
+		)
+		if declareErr != nil {
+			return declareErr
+		}
+
+		exchange, routingKey = p.config.Options.Delay.exchangeName(), queueName
+	}
+
+	err := p.publish(ctx, ch, exchange, routingKey, msg)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to publish message to exchange '%s' with routing key '%s': %w",
-			p.config.Options.Exchange.Name,
+			exchange,
 			routingKey,
 			err,
 		)
@@ -71,6 +109,112 @@ func (p *Producer) Send(ctx context.Context, env api.Envelope) error {
 	return nil
 }
 
-func (p *Producer) Close() error {
+func newPublishing(env api.Envelope, body []byte, headersMap map[string]string) amqp.Publishing {
+	headers := amqp.Table{}
+	for k, v := range headersMap {
+		headers[k] = v
+	}
+
+	msg := amqp.Publishing{
+		Headers:     headers,
+		ContentType: "application/json",
+		Body:        body,
+	}
+
+	if priorityStamp, ok := envelope.LastStampOf[stamps.PriorityStamp](env); ok {
+		msg.Priority = priorityStamp.Priority
+	}
+
+	if amqpStamp, ok := envelope.LastStampOf[AmqpStamp](env); ok {
+		amqpStamp.apply(&msg)
+	}
+
+	return msg
+}
+
+func (p *Producer) openChannel() (*publishChannel, error) {
+	ch, err := p.connection.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	pc := &publishChannel{Channel: ch}
+
+	if p.config.Options.Confirm || p.config.Options.Mandatory {
+		if err = ch.Confirm(false); err != nil {
+			_ = ch.Close()
+
+			return nil, fmt.Errorf("failed to enable confirm mode: %w", err)
+		}
+	}
+
+	if p.config.Options.Mandatory {
+		pc.returns = ch.NotifyReturn(make(chan amqp.Return, 1))
+	}
+
+	return pc, nil
+}
+
+func (p *Producer) publish(
+	ctx context.Context,
+	ch *publishChannel,
+	exchange string,
+	routingKey string,
+	msg amqp.Publishing,
+) error {
+	mandatory := p.config.Options.Mandatory
+
+	if !p.config.Options.Confirm && !mandatory {
+		return ch.PublishWithContext(ctx, exchange, routingKey, false, false, msg)
+	}
+
+	drainReturns(ch.returns)
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey, mandatory, false, msg)
+	if err != nil {
+		return err
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return confirmResult(acked, ch.returns)
+}
+
+func drainReturns(returns <-chan amqp.Return) {
+	for {
+		select {
+		case <-returns:
+		default:
+			return
+		}
+	}
+}
+
+func confirmResult(acked bool, returns <-chan amqp.Return) error {
+	select {
+	case ret, ok := <-returns:
+		if ok {
+			return fmt.Errorf("%w: %d %s", ErrMessageReturned, ret.ReplyCode, ret.ReplyText)
+		}
+	default:
+	}
+
+	if !acked {
+		return ErrMessageNotAcked
+	}
+
 	return nil
 }
+
+func (p *Producer) Close() error {
+	p.requestMu.Lock()
+	if p.replies != nil {
+		_ = p.replies.Close()
+	}
+	p.requestMu.Unlock()
+
+	return p.channels.close()
+}
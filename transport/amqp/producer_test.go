@@ -0,0 +1,80 @@
+package amqp
+
+import (
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/stamps"
+)
+
+func TestConfirmResult(t *testing.T) {
+	t.Run("acknowledged message", func(t *testing.T) {
+		require.NoError(t, confirmResult(true, make(chan amqp.Return, 1)))
+	})
+
+	t.Run("acknowledged without mandatory", func(t *testing.T) {
+		require.NoError(t, confirmResult(true, nil))
+	})
+
+	t.Run("nacked message", func(t *testing.T) {
+		require.ErrorIs(t, confirmResult(false, nil), ErrMessageNotAcked)
+	})
+
+	t.Run("returned message", func(t *testing.T) {
+		returns := make(chan amqp.Return, 1)
+		returns <- amqp.Return{ReplyCode: amqp.NoRoute, ReplyText: "NO_ROUTE"}
+
+		err := confirmResult(true, returns)
+
+		require.ErrorIs(t, err, ErrMessageReturned)
+		assert.Contains(t, err.Error(), "312 NO_ROUTE")
+	})
+}
+
+func TestNewPublishing(t *testing.T) {
+	t.Run("plain message", func(t *testing.T) {
+		env := envelope.NewEnvelope(&struct{}{})
+
+		msg := newPublishing(env, []byte(`{}`), map[string]string{"type": "test"})
+
+		assert.Equal(t, amqp.Table{"type": "test"}, msg.Headers)
+		assert.Equal(t, "application/json", msg.ContentType)
+		assert.Equal(t, []byte(`{}`), msg.Body)
+		assert.Zero(t, msg.Priority)
+	})
+
+	t.Run("priority stamp", func(t *testing.T) {
+		env := envelope.NewEnvelope(&struct{}{}).
+			WithStamp(stamps.PriorityStamp{Priority: 3}).
+			WithStamp(stamps.PriorityStamp{Priority: 7})
+
+		msg := newPublishing(env, nil, nil)
+
+		assert.Equal(t, uint8(7), msg.Priority)
+	})
+}
+
+func TestNewPublishing_AmqpStamp(t *testing.T) {
+	env := envelope.NewEnvelope(&struct{}{}).WithStamp(AmqpStamp{
+		DeliveryMode:  amqp.Persistent,
+		Expiration:    30 * time.Second,
+		CorrelationID: "correlation",
+		ReplyTo:       "replies",
+		MessageID:     "message",
+		AppID:         "billing",
+	})
+
+	msg := newPublishing(env, nil, nil)
+
+	assert.Equal(t, amqp.Persistent, msg.DeliveryMode)
+	assert.Equal(t, "30000", msg.Expiration)
+	assert.Equal(t, "correlation", msg.CorrelationId)
+	assert.Equal(t, "replies", msg.ReplyTo)
+	assert.Equal(t, "message", msg.MessageId)
+	assert.Equal(t, "billing", msg.AppId)
+}
@@ -0,0 +1,164 @@
+package amqp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/stamps"
+)
+
+const (
+	directReplyTo = "amq.rabbitmq.reply-to"
+)
+
+var ErrNoReplyAddress = errors.New("request has no reply address")
+
+func (p *Producer) Request(ctx context.Context, env api.Envelope) (api.Envelope, error) {
+	if !p.connection.IsConnect() {
+		return nil, ErrConnectionNotAvailable
+	}
+
+	requestStamp, _ := envelope.LastStampOf[stamps.RequestStamp](env)
+	env = env.WithStamp(stamps.RequestStamp{CorrelationID: requestStamp.CorrelationID, ReplyTo: directReplyTo})
+
+	body, headersMap, err := p.serializer.Marshal(env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal envelope: %w", err)
+	}
+
+	msg := newPublishing(env, body, headersMap)
+	msg.CorrelationId = requestStamp.CorrelationID
+	msg.ReplyTo = directReplyTo
+
+	return p.pending.Await(ctx, requestStamp.CorrelationID, func() error {
+		p.requestMu.Lock()
+		defer p.requestMu.Unlock()
+
+		ch, chErr := p.replyChannel()
+		if chErr != nil {
+			return chErr
+		}
+
+		return p.sendOn(ctx, ch, env, msg)
+	})
+}
+
+func (p *Producer) Reply(ctx context.Context, request api.Envelope, reply api.Envelope) error {
+	replyTo, correlationID := replyAddress(request)
+	if replyTo == "" {
+		return ErrNoReplyAddress
+	}
+
+	msg, err := p.replyPublishing(reply, correlationID)
+	if err != nil {
+		return err
+	}
+
+	ch, err := p.channels.get(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire AMQP channel: %w", err)
+	}
+
+	err = p.publish(ctx, ch, "", replyTo, msg)
+	p.channels.put(ch, err == nil || errors.Is(err, ErrMessageReturned))
+
+	if err != nil {
+		return fmt.Errorf("failed to publish reply to '%s': %w", replyTo, err)
+	}
+
+	return nil
+}
+
+func (p *Producer) replyPublishing(reply api.Envelope, correlationID string) (amqp.Publishing, error) {
+	body, headersMap, err := p.serializer.Marshal(reply)
+	if err != nil {
+		return amqp.Publishing{}, fmt.Errorf("failed to marshal reply: %w", err)
+	}
+
+	msg := newPublishing(reply, body, headersMap)
+	msg.CorrelationId = correlationID
+
+	return msg, nil
+}
+
+func (p *Producer) replyChannel() (*publishChannel, error) {
+	if p.replies != nil && !p.replies.IsClosed() {
+		return p.replies, nil
+	}
+
+	ch, err := p.openChannel()
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := ch.Consume(directReplyTo, "", true, false, false, false, nil)
+	if err != nil {
+		_ = ch.Close()
+
+		return nil, fmt.Errorf("failed to consume direct reply-to: %w", err)
+	}
+
+	go p.receiveReplies(deliveries)
+
+	p.replies = ch
+
+	return ch, nil
+}
+
+func (p *Producer) receiveReplies(deliveries <-chan amqp.Delivery) {
+	for d := range deliveries {
+		p.resolveReply(d)
+	}
+}
+
+func (p *Producer) resolveReply(d amqp.Delivery) {
+	reply, err := p.serializer.Unmarshal(d.Body, stringHeaders(d.Headers))
+	if err != nil {
+		p.pending.Reject(d.CorrelationId, fmt.Errorf("failed to decode reply: %w", err))
+
+		return
+	}
+
+	p.pending.Resolve(reply)
+}
+
+func replyAddress(request api.Envelope) (string, string) {
+	requestStamp, _ := envelope.LastStampOf[stamps.RequestStamp](request)
+	if requestStamp.ReplyTo == directReplyTo {
+		return "", requestStamp.CorrelationID
+	}
+
+	return requestStamp.ReplyTo, requestStamp.CorrelationID
+}
+
+// withReplyAddress keeps the broker-assigned direct reply-to address of a request. Only the
+// delivery carries it, so without this a retried or delayed request could not be answered.
+func withReplyAddress(env api.Envelope, d amqp.Delivery) api.Envelope {
+	requestStamp, ok := envelope.LastStampOf[stamps.RequestStamp](env)
+	if !ok || d.ReplyTo == "" {
+		return env
+	}
+
+	requestStamp.ReplyTo = d.ReplyTo
+	if d.CorrelationId != "" {
+		requestStamp.CorrelationID = d.CorrelationId
+	}
+
+	return env.WithStamp(requestStamp)
+}
+
+func stringHeaders(table amqp.Table) map[string]string {
+	headers := make(map[string]string, len(table))
+	for k, v := range table {
+		if s, ok := v.(string); ok {
+			headers[k] = s
+		}
+	}
+
+	return headers
+}
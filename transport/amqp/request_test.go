@@ -0,0 +1,91 @@
+package amqp
+
+import (
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/rpc"
+	"github.com/gerfey/messenger/core/stamps"
+	"github.com/gerfey/messenger/tests/helpers"
+)
+
+func testReply(t *testing.T) api.Envelope {
+	t.Helper()
+
+	request := envelope.NewEnvelope(&helpers.TestMessage{Content: "ping"}).
+		WithStamp(stamps.RequestStamp{CorrelationID: "42"})
+	handled := request.WithStamp(stamps.HandledStamp{Handler: "handler", Result: &helpers.TestMessage{Content: "pong"}})
+
+	return rpc.NewReply(request, handled, nil)
+}
+
+func TestProducer_ReplyRoundTrip(t *testing.T) {
+	worker := &Producer{serializer: helpers.NewTestSerializer(&helpers.TestMessage{})}
+
+	msg, err := worker.replyPublishing(testReply(t), "42")
+	require.NoError(t, err)
+
+	delivery := amqp.Delivery{Body: msg.Body, Headers: msg.Headers, CorrelationId: msg.CorrelationId}
+
+	t.Run("decodes registered reply type", func(t *testing.T) {
+		requester := &Producer{
+			serializer: helpers.NewTestSerializer(&helpers.TestMessage{}),
+			pending:    rpc.NewPending(),
+		}
+
+		reply, err := requester.pending.Await(t.Context(), "42", func() error {
+			requester.resolveReply(delivery)
+
+			return nil
+		})
+		require.NoError(t, err)
+
+		handled, err := rpc.Result(reply)
+		require.NoError(t, err)
+		assert.Equal(t, &helpers.TestMessage{Content: "pong"}, handled.Result)
+	})
+
+	t.Run("fails the request when the reply type is unknown", func(t *testing.T) {
+		requester := &Producer{serializer: helpers.NewTestSerializer(), pending: rpc.NewPending()}
+
+		_, err := requester.pending.Await(t.Context(), "42", func() error {
+			requester.resolveReply(delivery)
+
+			return nil
+		})
+
+		require.ErrorContains(t, err, "unknown message type: *helpers.TestMessage")
+	})
+}
+
+func TestReplyAddress(t *testing.T) {
+	request := envelope.NewEnvelope(&helpers.TestMessage{}).
+		WithStamp(stamps.RequestStamp{CorrelationID: "42", ReplyTo: directReplyTo})
+
+	t.Run("keeps the broker-assigned address across retries", func(t *testing.T) {
+		received := withReplyAddress(request, amqp.Delivery{ReplyTo: directReplyTo + ".g2dkAA", CorrelationId: "42"})
+
+		serializer := helpers.NewTestSerializer(&helpers.TestMessage{})
+		body, headers, err := serializer.Marshal(received)
+		require.NoError(t, err)
+
+		retried, err := serializer.Unmarshal(body, headers)
+		require.NoError(t, err)
+		retried = withReplyAddress(retried, amqp.Delivery{})
+
+		replyTo, correlationID := replyAddress(retried)
+		assert.Equal(t, directReplyTo+".g2dkAA", replyTo)
+		assert.Equal(t, "42", correlationID)
+	})
+
+	t.Run("has no address without the broker-assigned one", func(t *testing.T) {
+		replyTo, _ := replyAddress(request)
+
+		assert.Empty(t, replyTo)
+	})
+}
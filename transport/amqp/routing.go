@@ -0,0 +1,60 @@
+package amqp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/stamps"
+)
+
+const (
+	routingKeyStrategyTypeName  = "type_name"
+	routingKeyStrategyShortName = "short_name"
+	routingKeyStrategyConstant  = "constant"
+)
+
+func validateRoutingKey(cfg RoutingKeyConfig) error {
+	switch cfg.Strategy {
+	case "", routingKeyStrategyTypeName, routingKeyStrategyShortName:
+		return nil
+	case routingKeyStrategyConstant:
+		if cfg.Value == "" {
+			return errors.New("routing_key strategy constant requires a value")
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown routing_key strategy %q, expected type_name, short_name or constant", cfg.Strategy)
+	}
+}
+
+func (p *Producer) routingKey(env api.Envelope) string {
+	if stamp, ok := envelope.LastStampOf[stamps.RoutingKeyStamp](env); ok && stamp.Key != "" {
+		return stamp.Key
+	}
+
+	if routed, ok := env.Message().(api.RoutedMessage); ok {
+		return routed.RoutingKey()
+	}
+
+	return defaultRoutingKey(p.config.Options.RoutingKey, env.Message())
+}
+
+func defaultRoutingKey(cfg RoutingKeyConfig, msg any) string {
+	switch cfg.Strategy {
+	case routingKeyStrategyConstant:
+		return cfg.Value
+	case routingKeyStrategyShortName:
+		t := reflect.TypeOf(msg)
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		return t.Name()
+	default:
+		return reflect.TypeOf(msg).String()
+	}
+}
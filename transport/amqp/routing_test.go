@@ -0,0 +1,70 @@
+package amqp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/stamps"
+	"github.com/gerfey/messenger/tests/helpers"
+)
+
+type routedMessage struct{}
+
+func (routedMessage) RoutingKey() string {
+	return "orders.routed"
+}
+
+func TestProducer_RoutingKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		config RoutingKeyConfig
+		env    api.Envelope
+		want   string
+	}{
+		{
+			name: "routing key stamp wins over routed message",
+			env:  envelope.NewEnvelope(routedMessage{}).WithStamp(stamps.RoutingKeyStamp{Key: "eu.orders"}),
+			want: "eu.orders",
+		},
+		{
+			name: "routed message",
+			env:  envelope.NewEnvelope(routedMessage{}),
+			want: "orders.routed",
+		},
+		{
+			name:   "type name strategy",
+			config: RoutingKeyConfig{Strategy: "type_name"},
+			env:    envelope.NewEnvelope(&helpers.TestMessage{}),
+			want:   "*helpers.TestMessage",
+		},
+		{
+			name:   "short name strategy",
+			config: RoutingKeyConfig{Strategy: "short_name"},
+			env:    envelope.NewEnvelope(&helpers.TestMessage{}),
+			want:   "TestMessage",
+		},
+		{
+			name:   "constant strategy",
+			config: RoutingKeyConfig{Strategy: "constant", Value: "messages"},
+			env:    envelope.NewEnvelope(&helpers.TestMessage{}),
+			want:   "messages",
+		},
+		{
+			name:   "empty routing key stamp is ignored",
+			config: RoutingKeyConfig{Strategy: "constant", Value: "messages"},
+			env:    envelope.NewEnvelope(&helpers.TestMessage{}).WithStamp(stamps.RoutingKeyStamp{}),
+			want:   "messages",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			producer := &Producer{config: TransportConfig{Options: OptionsConfig{RoutingKey: tt.config}}}
+
+			assert.Equal(t, tt.want, producer.routingKey(tt.env))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package amqp
+
+import (
+	"strconv"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+type AmqpStamp struct {
+	RoutingKey    string
+	Exchange      string
+	DeliveryMode  uint8 // amqp.Transient or amqp.Persistent
+	Expiration    time.Duration
+	CorrelationID string
+	ReplyTo       string
+	MessageID     string
+	AppID         string
+}
+
+type AmqpReceivedStamp struct {
+	Exchange      string
+	RoutingKey    string
+	Redelivered   bool
+	DeliveryTag   uint64
+	CorrelationID string
+	ReplyTo       string
+}
+
+func (s AmqpStamp) apply(msg *amqp.Publishing) {
+	if s.DeliveryMode != 0 {
+		msg.DeliveryMode = s.DeliveryMode
+	}
+
+	if s.Expiration > 0 {
+		msg.Expiration = strconv.FormatInt(s.Expiration.Milliseconds(), 10)
+	}
+
+	if s.CorrelationID != "" {
+		msg.CorrelationId = s.CorrelationID
+	}
+
+	if s.ReplyTo != "" {
+		msg.ReplyTo = s.ReplyTo
+	}
+
+	if s.MessageID != "" {
+		msg.MessageId = s.MessageID
+	}
+
+	if s.AppID != "" {
+		msg.AppId = s.AppID
+	}
+}
+
+func receivedStampOf(d amqp.Delivery) AmqpReceivedStamp {
+	return AmqpReceivedStamp{
+		Exchange:      d.Exchange,
+		RoutingKey:    d.RoutingKey,
+		Redelivered:   d.Redelivered,
+		DeliveryTag:   d.DeliveryTag,
+		CorrelationID: d.CorrelationId,
+		ReplyTo:       d.ReplyTo,
+	}
+}
@@ -3,7 +3,6 @@ package amqp
 import (
 	"context"
 	"fmt"
-	"reflect"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 
@@ -14,12 +13,14 @@ type ConnectionAMQP interface {
 	Channel() (*amqp.Channel, error)
 	Connect() error
 	IsConnect() bool
+	OnReconnect(func(context.Context) error)
+	Recovered() <-chan struct{}
 	Close() error
 }
 
 type Transport struct {
 	config     TransportConfig
-	producer   api.Producer
+	producer   api.RequestReplyProducer
 	consumer   api.Consumer
 	connection ConnectionAMQP
 }
@@ -27,8 +28,9 @@ type Transport struct {
 func NewTransport(
 	config TransportConfig,
 	serializer api.Serializer,
+	eventDispatcher api.EventDispatcher,
 ) (api.Transport, error) {
-	connection, errConnection := NewConnection(config.DSN)
+	connection, errConnection := NewConnection(config, eventDispatcher)
 	if errConnection != nil {
 		return nil, fmt.Errorf("failed to create connection: %w", errConnection)
 	}
@@ -43,12 +45,16 @@ func NewTransport(
 		return nil, fmt.Errorf("failed to create producer: %w", errConsumer)
 	}
 
-	return &Transport{
+	t := &Transport{
 		config:     config,
 		producer:   producer,
 		consumer:   consumer,
 		connection: connection,
-	}, nil
+	}
+
+	connection.OnReconnect(t.Setup)
+
+	return t, nil
 }
 
 func (t *Transport) Name() string {
@@ -59,6 +65,14 @@ func (t *Transport) Send(ctx context.Context, env api.Envelope) error {
 	return t.producer.Send(ctx, env)
 }
 
+func (t *Transport) Request(ctx context.Context, env api.Envelope) (api.Envelope, error) {
+	return t.producer.Request(ctx, env)
+}
+
+func (t *Transport) Reply(ctx context.Context, request api.Envelope, reply api.Envelope) error {
+	return t.producer.Reply(ctx, request, reply)
+}
+
 func (t *Transport) Receive(ctx context.Context, handler func(context.Context, api.Envelope) error) error {
 	return t.consumer.Consume(ctx, handler)
 }
@@ -80,48 +94,100 @@ func (t *Transport) Setup(_ context.Context) error {
 		_ = ch.Close()
 	}()
 
-	err = ch.ExchangeDeclare(
-		t.config.Options.Exchange.Name,
-		t.config.Options.Exchange.Type,
-		t.config.Options.Exchange.Durable,
-		t.config.Options.Exchange.AutoDelete,
-		t.config.Options.Exchange.Internal,
+	exchanges := append([]ExchangeConfig{t.config.Options.Exchange}, t.config.Options.Exchanges...)
+
+	for _, exchangeCfg := range exchanges {
+		err = ch.ExchangeDeclare(
+			exchangeCfg.Name,
+			exchangeCfg.Type,
+			exchangeCfg.Durable,
+			exchangeCfg.AutoDelete,
+			exchangeCfg.Internal,
+			false,
+			toTable(exchangeCfg.Arguments),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to declare exchange '%s': %w", exchangeCfg.Name, err)
+		}
+	}
+
+	for _, exchangeCfg := range exchanges {
+		if err = bindExchange(ch, exchangeCfg); err != nil {
+			return err
+		}
+	}
+
+	if err = declareDelayExchange(ch, t.config.Options.Delay); err != nil {
+		return err
+	}
+
+	for queueName, queueCfg := range t.config.Options.Queues {
+		if err = t.declareQueue(ch, queueName, queueCfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *Transport) declareQueue(ch *amqp.Channel, queueName string, queueCfg Queue) error {
+	_, err := ch.QueueDeclare(
+		queueName,
+		queueCfg.Durable,
+		queueCfg.AutoDelete,
+		queueCfg.Exclusive,
 		false,
-		nil,
+		toTable(queueCfg.Arguments),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to declare exchange: %w", err)
+		return fmt.Errorf("declare queue: %w", err)
 	}
 
-	for queueName, queueCfg := range t.config.Options.Queues {
-		_, err = ch.QueueDeclare(
+	exchange := queueCfg.Exchange
+	if exchange == "" {
+		exchange = t.config.Options.Exchange.Name
+	}
+
+	bindingKeys := queueCfg.BindingKeys
+
+	if len(bindingKeys) == 0 {
+		bindingKeys = []string{""}
+	}
+
+	for _, bindingKey := range bindingKeys {
+		bindErr := ch.QueueBind(
 			queueName,
-			queueCfg.Durable,
-			queueCfg.AutoDelete,
-			queueCfg.Exclusive,
+			bindingKey,
+			exchange,
 			false,
-			nil,
+			toTable(queueCfg.BindingArguments),
 		)
-		if err != nil {
-			return fmt.Errorf("declare queue: %w", err)
+		if bindErr != nil {
+			return fmt.Errorf("bind queue: %w", bindErr)
 		}
+	}
 
-		bindingKeys := queueCfg.BindingKeys
+	return nil
+}
+
+func bindExchange(ch *amqp.Channel, exchangeCfg ExchangeConfig) error {
+	for _, binding := range exchangeCfg.Bindings {
+		bindingKeys := binding.BindingKeys
 
 		if len(bindingKeys) == 0 {
 			bindingKeys = []string{""}
 		}
 
 		for _, bindingKey := range bindingKeys {
-			bindErr := ch.QueueBind(
-				queueName,
-				bindingKey,
-				t.config.Options.Exchange.Name,
-				false,
-				nil,
-			)
-			if bindErr != nil {
-				return fmt.Errorf("bind queue: %w", bindErr)
+			err := ch.ExchangeBind(exchangeCfg.Name, bindingKey, binding.Source, false, toTable(binding.Arguments))
+			if err != nil {
+				return fmt.Errorf(
+					"failed to bind exchange '%s' to '%s' with key '%s': %w",
+					exchangeCfg.Name,
+					binding.Source,
+					bindingKey,
+					err,
+				)
 			}
 		}
 	}
@@ -130,20 +196,13 @@ func (t *Transport) Setup(_ context.Context) error {
 }
 
 func (t *Transport) Close() error {
+	if err := t.producer.Close(); err != nil {
+		return fmt.Errorf("failed to close producer: %w", err)
+	}
+
 	if err := t.connection.Close(); err != nil {
 		return fmt.Errorf("failed to close connection: %w", err)
 	}
 
 	return nil
 }
-
-func getRoutingKey(msg any) string {
-	var routingKey string
-	if rk, ok := msg.(api.RoutedMessage); ok {
-		routingKey = rk.RoutingKey()
-	} else {
-		routingKey = reflect.TypeOf(msg).String()
-	}
-
-	return routingKey
-}
@@ -2,25 +2,41 @@ package inmemory
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/ack"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/rpc"
 	"github.com/gerfey/messenger/core/stamps"
 )
 
 const sleepDuration = 10 * time.Millisecond
 
 type Transport struct {
-	name  string
-	queue []api.Envelope
-	lock  sync.Mutex
+	name    string
+	queue   []queuedEnvelope
+	nextID  uint64
+	lock    sync.Mutex
+	pending *rpc.Pending
+}
+
+type queuedEnvelope struct {
+	id          string
+	env         api.Envelope
+	availableAt time.Time
 }
 
 func NewTransport(name string) api.Transport {
 	return &Transport{
-		name:  name,
-		queue: make([]api.Envelope, 0),
+		name:    name,
+		queue:   make([]queuedEnvelope, 0),
+		pending: rpc.NewPending(),
 	}
 }
 
@@ -32,33 +48,108 @@ func (t *Transport) Send(_ context.Context, env api.Envelope) error {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
-	t.queue = append(t.queue, env)
+	availableAt := time.Now()
+	if delayStamp, ok := envelope.LastStampOf[stamps.DelayStamp](env); ok && delayStamp.Milliseconds > 0 {
+		availableAt = availableAt.Add(delayStamp.Duration())
+	}
+
+	idx := sort.Search(len(t.queue), func(i int) bool {
+		return t.queue[i].availableAt.After(availableAt)
+	})
+
+	t.nextID++
+
+	t.queue = append(t.queue, queuedEnvelope{})
+	copy(t.queue[idx+1:], t.queue[idx:])
+	t.queue[idx] = queuedEnvelope{
+		id:          strconv.FormatUint(t.nextID, 10),
+		env:         env,
+		availableAt: availableAt,
+	}
+
+	return nil
+}
+
+func (t *Transport) Request(ctx context.Context, env api.Envelope) (api.Envelope, error) {
+	requestStamp, _ := envelope.LastStampOf[stamps.RequestStamp](env)
+	env = env.WithStamp(stamps.RequestStamp{CorrelationID: requestStamp.CorrelationID, ReplyTo: t.name})
+
+	return t.pending.Await(ctx, requestStamp.CorrelationID, func() error {
+		return t.Send(ctx, env)
+	})
+}
+
+func (t *Transport) Reply(_ context.Context, _ api.Envelope, reply api.Envelope) error {
+	t.pending.Resolve(reply)
 
 	return nil
 }
 
+func (t *Transport) Retry(ctx context.Context, env api.Envelope) error {
+	return t.Send(ctx, env)
+}
+
+func (t *Transport) List(_ context.Context, limit int) ([]api.Envelope, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	envs := make([]api.Envelope, 0, len(t.queue))
+	for _, item := range t.queue {
+		if limit > 0 && len(envs) >= limit {
+			break
+		}
+
+		envs = append(envs, item.env.WithStamp(stamps.TransportMessageIDStamp{ID: item.id}))
+	}
+
+	return envs, nil
+}
+
+func (t *Transport) Find(_ context.Context, id string) (api.Envelope, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for _, item := range t.queue {
+		if item.id == id {
+			return item.env.WithStamp(stamps.TransportMessageIDStamp{ID: item.id}), nil
+		}
+	}
+
+	return nil, fmt.Errorf("message '%s' not found", id)
+}
+
+func (t *Transport) Remove(_ context.Context, id string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for i, item := range t.queue {
+		if item.id == id {
+			t.queue = append(t.queue[:i], t.queue[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("message '%s' not found", id)
+}
+
 func (t *Transport) Receive(ctx context.Context, handler func(context.Context, api.Envelope) error) error {
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			t.lock.Lock()
-			if len(t.queue) == 0 {
-				t.lock.Unlock()
-
+			env, ok := t.next()
+			if !ok {
 				time.Sleep(sleepDuration)
 
 				continue
 			}
 
-			env := t.queue[0]
-			t.queue = t.queue[1:]
-			t.lock.Unlock()
-
 			envWithReceivedStamp := env.WithStamp(stamps.ReceivedStamp{Transport: t.name})
 
-			if err := handler(ctx, envWithReceivedStamp); err != nil {
+			err := handler(ctx, envWithReceivedStamp.WithStamp(stamps.AckStamp{Acknowledger: ack.New(func(error) {})}))
+			if err != nil && !errors.Is(err, api.ErrMessageHandedOff) {
 				return err
 			}
 		}
@@ -73,3 +164,17 @@ func (t *Transport) Close() error {
 
 	return nil
 }
+
+func (t *Transport) next() (api.Envelope, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if len(t.queue) == 0 || t.queue[0].availableAt.After(time.Now()) {
+		return nil, false
+	}
+
+	item := t.queue[0]
+	t.queue = t.queue[1:]
+
+	return item.env, true
+}
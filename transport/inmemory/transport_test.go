@@ -3,6 +3,7 @@ package inmemory_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -179,6 +180,61 @@ func TestTransport_Receive(t *testing.T) {
 		assert.Equal(t, expectedError, err)
 	})
 
+	t.Run("receive continues after handed off message", func(t *testing.T) {
+		transport := inmemory.NewTransport("test-transport")
+
+		require.NoError(t, transport.Send(t.Context(), envelope.NewEnvelope(&helpers.TestMessage{Content: "test1"})))
+		require.NoError(t, transport.Send(t.Context(), envelope.NewEnvelope(&helpers.TestMessage{Content: "test2"})))
+
+		var calls int
+		handler := func(_ context.Context, _ api.Envelope) error {
+			calls++
+
+			return fmt.Errorf("%w: handler error", api.ErrMessageHandedOff)
+		}
+
+		ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+		defer cancel()
+
+		err := transport.Receive(ctx, handler)
+
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("delayed message is received after delay", func(t *testing.T) {
+		transport := inmemory.NewTransport("test-transport")
+
+		delayed := envelope.NewEnvelope(&helpers.TestMessage{Content: "delayed"}).
+			WithStamp(stamps.DelayStamp{Milliseconds: 50})
+		immediate := envelope.NewEnvelope(&helpers.TestMessage{Content: "immediate"})
+
+		require.NoError(t, transport.Send(t.Context(), delayed))
+		require.NoError(t, transport.Send(t.Context(), immediate))
+
+		start := time.Now()
+		var received []string
+		var delayedAfter time.Duration
+		handler := func(_ context.Context, env api.Envelope) error {
+			content := env.Message().(*helpers.TestMessage).Content
+			received = append(received, content)
+			if content == "delayed" {
+				delayedAfter = time.Since(start)
+			}
+
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(t.Context(), 150*time.Millisecond)
+		defer cancel()
+
+		err := transport.Receive(ctx, handler)
+		assert.Equal(t, context.DeadlineExceeded, err)
+
+		assert.Equal(t, []string{"immediate", "delayed"}, received)
+		assert.GreaterOrEqual(t, delayedAfter, 50*time.Millisecond)
+	})
+
 	t.Run("receive with cancelled context", func(t *testing.T) {
 		transport := inmemory.NewTransport("test-transport")
 
@@ -213,6 +269,33 @@ func TestTransport_Receive(t *testing.T) {
 	})
 }
 
+func TestTransport_Retry(t *testing.T) {
+	t.Run("retry honours delay stamp", func(t *testing.T) {
+		transport := inmemory.NewTransport("test-transport").(*inmemory.Transport)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{Content: "retry"}).
+			WithStamp(stamps.RedeliveryStamp{RetryCount: 1}).
+			WithStamp(stamps.DelayStamp{Milliseconds: 30})
+
+		require.NoError(t, transport.Retry(t.Context(), env))
+
+		var receivedAt time.Duration
+		start := time.Now()
+		handler := func(_ context.Context, _ api.Envelope) error {
+			receivedAt = time.Since(start)
+
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+		defer cancel()
+
+		_ = transport.Receive(ctx, handler)
+
+		assert.GreaterOrEqual(t, receivedAt, 30*time.Millisecond)
+	})
+}
+
 func TestTransport_Integration(t *testing.T) {
 	t.Run("full send and receive workflow", func(t *testing.T) {
 		transport := inmemory.NewTransport("integration-transport")
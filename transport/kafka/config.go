@@ -1,6 +1,9 @@
 package kafka
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 type TransportConfig struct {
 	Name    string
@@ -9,11 +12,31 @@ type TransportConfig struct {
 }
 
 type OptionsConfig struct {
-	Topics   []string              `yaml:"topics,omitempty"`
-	Group    string                `yaml:"group"            default:"default-group"`
-	Producer ProducerOptionsConfig `yaml:"producer"`
-	Consumer ConsumerOptionsConfig `yaml:"consumer"`
-	Key      KeyConfig             `yaml:"key"`
+	Topics      []string              `yaml:"topics,omitempty"`
+	Group       string                `yaml:"group"            default:"default-group"`
+	AutoSetup   bool                  `yaml:"auto_setup"       default:"false"`
+	Setup       SetupConfig           `yaml:"setup"`
+	Producer    ProducerOptionsConfig `yaml:"producer"`
+	Consumer    ConsumerOptionsConfig `yaml:"consumer"`
+	Key         KeyConfig             `yaml:"key"`
+	Topic       TopicConfig           `yaml:"topic"`
+	DelayTopics DelayTopicsConfig     `yaml:"delay_topics"`
+	RetryTopics RetryTopicsConfig     `yaml:"retry_topics"`
+	Reply       ReplyConfig           `yaml:"reply"`
+	TLS         TLSConfig             `yaml:"tls"`
+	SASL        SASLConfig            `yaml:"sasl"`
+}
+
+// routableTopics lists the configured topics followed by the extra routing targets.
+func (c *TransportConfig) routableTopics() []string {
+	topics := slices.Clone(c.Options.Topics)
+	for _, topic := range c.Options.Topic.Routes {
+		if !slices.Contains(topics, topic) {
+			topics = append(topics, topic)
+		}
+	}
+
+	return topics
 }
 
 type ProducerOptionsConfig struct {
@@ -25,6 +48,14 @@ type ProducerOptionsConfig struct {
 	WriteTimeout      time.Duration `yaml:"write_timeout"       default:"10s"`
 	ReadTimeout       time.Duration `yaml:"read_timeout"        default:"10s"`
 	Balancer          string        `yaml:"balancer"            default:"round_robin"` // least_bytes, hash, round_robin
+	Outbox            OutboxConfig  `yaml:"outbox"`
+}
+
+// OutboxConfig makes a send to several topics atomic: it is written once to the outbox topic
+// and the consumer relays it to every target topic.
+type OutboxConfig struct {
+	Enabled bool   `yaml:"enabled" default:"false"`
+	Topic   string `yaml:"topic"` // default <first topic>.outbox
 }
 
 type ConsumerOptionsConfig struct {
@@ -34,6 +65,7 @@ type ConsumerOptionsConfig struct {
 	Rebalance         RebalanceConfig `yaml:"rebalance"`
 	SessionTimeout    time.Duration   `yaml:"session_timeout"    default:"10s"`
 	HeartbeatInterval time.Duration   `yaml:"heartbeat_interval" default:"2s"`
+	Ordering          string          `yaml:"ordering"           default:"none"` // none, partition, key
 }
 
 type OffsetConfig struct {
@@ -58,5 +90,50 @@ type RebalanceConfig struct {
 }
 
 type KeyConfig struct {
-	Strategy string `yaml:"strategy" default:"none"` // none, message_id
+	Strategy string `yaml:"strategy" default:"none"` // none, message_id, routing_key, partition_key
+}
+
+type TopicConfig struct {
+	Strategy string   `yaml:"strategy" default:"static"` // static, routing_key
+	Routes   []string `yaml:"routes"`                    // further topics messages may be routed to
+}
+
+type SetupConfig struct {
+	Partitions        int               `yaml:"partitions"`         // 1 when unset, existing topics are checked only when set
+	ReplicationFactor int               `yaml:"replication_factor"` // 1 when unset, existing topics are checked only when set
+	Retention         time.Duration     `yaml:"retention"`          // configured topics only
+	CleanupPolicy     string            `yaml:"cleanup_policy"`     // configured topics only: delete, compact, "compact,delete"
+	Configs           map[string]string `yaml:"configs"`
+}
+
+type DelayTopicsConfig struct {
+	Tiers []time.Duration `yaml:"tiers"` // <topic>.delay.<tier> topics, default 1s, 10s, 1m, 10m, 1h
+}
+
+type RetryTopicsConfig struct {
+	Enabled bool `yaml:"enabled" default:"false"`
+	Levels  int  `yaml:"levels"  default:"3"`
+}
+
+type ReplyConfig struct {
+	Topic  string   `yaml:"topic"`  // single-partition topic this transport reads replies from
+	Topics []string `yaml:"topics"` // further reply topics of other requesters this transport answers to
+}
+
+func (c ReplyConfig) accepts(topic string) bool {
+	return topic == c.Topic || slices.Contains(c.Topics, topic)
+}
+
+type TLSConfig struct {
+	Enabled            bool   `yaml:"enabled"              default:"false"`
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify" default:"false"`
+}
+
+type SASLConfig struct {
+	Mechanism string `yaml:"mechanism"` // plain, scram-sha-256, scram-sha-512
+	Username  string `yaml:"username"`
+	Password  string `yaml:"password"`
 }
@@ -2,10 +2,12 @@ package kafka
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"time"
 
 	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
 )
 
 const (
@@ -13,16 +15,24 @@ const (
 )
 
 type Connection struct {
-	brokers []string
-	dialer  *kafka.Dialer
+	brokers   []string
+	dialer    *kafka.Dialer
+	transport *kafka.Transport
 }
 
-func NewConnection(brokers []string) (ConnectionKafka, error) {
+func NewConnection(brokers []string, tlsConfig *tls.Config, mechanism sasl.Mechanism) (ConnectionKafka, error) {
 	conn := &Connection{
 		brokers: brokers,
 		dialer: &kafka.Dialer{
-			Timeout:   connectionTimeout,
-			DualStack: true,
+			Timeout:       connectionTimeout,
+			DualStack:     true,
+			TLS:           tlsConfig,
+			SASLMechanism: mechanism,
+		},
+		transport: &kafka.Transport{
+			DialTimeout: connectionTimeout,
+			TLS:         tlsConfig,
+			SASL:        mechanism,
 		},
 	}
 
@@ -48,6 +58,7 @@ func (c *Connection) CreateWriter(
 ) *kafka.Writer {
 	return &kafka.Writer{
 		Addr:                   kafka.TCP(c.brokers...),
+		Transport:              c.transport,
 		Topic:                  topic,
 		RequiredAcks:           kafka.RequiredAcks(opts.RequiredAcks),
 		Async:                  async,
@@ -60,6 +71,14 @@ func (c *Connection) CreateWriter(
 	}
 }
 
+func (c *Connection) Admin() *kafka.Client {
+	return &kafka.Client{
+		Addr:      kafka.TCP(c.brokers...),
+		Timeout:   connectionTimeout,
+		Transport: c.transport,
+	}
+}
+
 func (c *Connection) check(timeout time.Duration) error {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
@@ -2,6 +2,7 @@ package kafka
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/segmentio/kafka-go"
 
 	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/ack"
 	"github.com/gerfey/messenger/core/stamps"
 )
 
@@ -19,6 +21,7 @@ const (
 	defaultPoolSize   = 10
 	readLagInterval   = -1
 	errorBackoffDelay = 100 * time.Millisecond
+	maxRedeliverDelay = 30 * time.Second
 )
 
 type Consumer struct {
@@ -26,10 +29,15 @@ type Consumer struct {
 	serializer      api.Serializer
 	connection      ConnectionKafka
 	readers         []*kafka.Reader
+	delayReaders    []*kafka.Reader
+	delayWriters    map[string]*kafka.Writer
 	wg              sync.WaitGroup
 	batchMutex      sync.Mutex
 	batchMessages   []kafka.Message
 	deferredCommits sync.Map
+	offsets         *offsetTracker
+	deadLetters     map[string]*kafka.Writer
+	outboxWriter    *kafka.Writer
 }
 
 type messageWithReader struct {
@@ -39,48 +47,35 @@ type messageWithReader struct {
 
 func NewConsumer(config TransportConfig, connection ConnectionKafka, serializer api.Serializer) (api.Consumer, error) {
 	return &Consumer{
-		config:     config,
-		connection: connection,
-		serializer: serializer,
-		readers:    make([]*kafka.Reader, 0),
+		config:       config,
+		connection:   connection,
+		serializer:   serializer,
+		readers:      make([]*kafka.Reader, 0),
+		offsets:      newOffsetTracker(maxPendingOffsets),
+		delayWriters: make(map[string]*kafka.Writer),
+		deadLetters:  make(map[string]*kafka.Writer),
 	}, nil
 }
 
 func (c *Consumer) Consume(ctx context.Context, handler func(context.Context, api.Envelope) error) error {
 	for _, topic := range c.config.Options.Topics {
-		readerConfig := kafka.ReaderConfig{
-			GroupID:           c.config.Options.Group,
-			Topic:             topic,
-			CommitInterval:    c.config.Options.Consumer.Commit.Interval,
-			MinBytes:          minBytes,
-			MaxBytes:          maxBytes,
-			ReadLagInterval:   readLagInterval,
-			SessionTimeout:    c.config.Options.Consumer.SessionTimeout,
-			RebalanceTimeout:  rebalanceTimeout,
-			HeartbeatInterval: c.config.Options.Consumer.HeartbeatInterval,
-			MaxWait:           time.Second,
-		}
+		reader := c.connection.CreateReader(c.readerConfig(topic))
+		c.readers = append(c.readers, reader)
+	}
 
-		switch c.config.Options.Consumer.Rebalance.Strategy {
-		case "range":
-			readerConfig.GroupBalancers = []kafka.GroupBalancer{kafka.RangeGroupBalancer{}}
-		case "roundrobin":
-			readerConfig.GroupBalancers = []kafka.GroupBalancer{kafka.RoundRobinGroupBalancer{}}
-		}
+	c.startDelayRelays(ctx)
 
-		c.configureOffset(&readerConfig)
-		reader := c.connection.CreateReader(readerConfig)
-		c.readers = append(c.readers, reader)
+	if c.config.outboxEnabled() {
+		c.startOutboxRelay(ctx)
 	}
 
-	jobs := make(chan job)
-	c.startWorkerPool(ctx, jobs, handler)
+	queues := c.startWorkerPool(ctx, handler)
 
 	for _, reader := range c.readers {
 		c.wg.Add(1)
 		go func(r *kafka.Reader) {
 			defer c.wg.Done()
-			c.fetchMessages(ctx, r, jobs)
+			c.fetchMessages(ctx, r, queues)
 		}(reader)
 	}
 
@@ -90,7 +85,9 @@ func (c *Consumer) Consume(ctx context.Context, handler func(context.Context, ap
 
 	<-ctx.Done()
 
-	close(jobs)
+	for _, queue := range queues {
+		close(queue)
+	}
 	c.wg.Wait()
 
 	return ctx.Err()
@@ -104,9 +101,99 @@ func (c *Consumer) Close() error {
 		}
 	}
 
+	for _, reader := range c.delayReaders {
+		err := reader.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, writer := range c.delayWriters {
+		err := writer.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, writer := range c.deadLetters {
+		err := writer.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.outboxWriter != nil {
+		return c.outboxWriter.Close()
+	}
+
 	return nil
 }
 
+func (c *Consumer) readerConfig(topic string) kafka.ReaderConfig {
+	readerConfig := kafka.ReaderConfig{
+		GroupID:           c.config.Options.Group,
+		Topic:             topic,
+		CommitInterval:    c.config.Options.Consumer.Commit.Interval,
+		MinBytes:          minBytes,
+		MaxBytes:          maxBytes,
+		ReadLagInterval:   readLagInterval,
+		SessionTimeout:    c.config.Options.Consumer.SessionTimeout,
+		RebalanceTimeout:  rebalanceTimeout,
+		HeartbeatInterval: c.config.Options.Consumer.HeartbeatInterval,
+		MaxWait:           time.Second,
+	}
+
+	switch c.config.Options.Consumer.Rebalance.Strategy {
+	case "range":
+		readerConfig.GroupBalancers = []kafka.GroupBalancer{kafka.RangeGroupBalancer{}}
+	case "roundrobin":
+		readerConfig.GroupBalancers = []kafka.GroupBalancer{kafka.RoundRobinGroupBalancer{}}
+	}
+
+	c.configureOffset(&readerConfig)
+
+	return readerConfig
+}
+
+func (c *Consumer) startDelayRelays(ctx context.Context) {
+	for _, topic := range c.config.routableTopics() {
+		c.delayWriters[topic] = c.connection.CreateWriter(topic, c.config.Options.Producer, false, &kafka.Hash{})
+
+		c.deadLetters[topic] = c.connection.CreateWriter(
+			deadLetterTopicName(topic),
+			c.config.Options.Producer,
+			false,
+			&kafka.Hash{},
+		)
+
+		for _, tier := range c.config.delayTiers() {
+			name := delayTopicName(topic, tier)
+			c.delayWriters[name] = c.connection.CreateWriter(name, c.config.Options.Producer, false, &kafka.Hash{})
+			c.startRelay(ctx, name, topic, tier)
+		}
+
+		if c.config.Options.RetryTopics.Enabled {
+			for level := 1; level <= c.config.retryLevels(); level++ {
+				c.startRelay(ctx, retryTopicName(topic, level), topic, 0)
+			}
+		}
+	}
+}
+
+func (c *Consumer) startRelay(ctx context.Context, relayTopic string, topic string, hold time.Duration) {
+	readerConfig := c.readerConfig(relayTopic)
+	readerConfig.StartOffset = kafka.FirstOffset
+
+	reader := c.connection.CreateReader(readerConfig)
+	c.delayReaders = append(c.delayReaders, reader)
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.relayDelayed(ctx, reader, topic, hold)
+	}()
+}
+
 func (c *Consumer) configureOffset(config *kafka.ReaderConfig) {
 	switch c.config.Options.Consumer.OffsetConfig.Type {
 	case "earliest":
@@ -120,18 +207,33 @@ func (c *Consumer) configureOffset(config *kafka.ReaderConfig) {
 
 func (c *Consumer) startWorkerPool(
 	ctx context.Context,
-	jobs chan job,
 	handler func(context.Context, api.Envelope) error,
-) {
+) []chan job {
 	poolSize := c.config.Options.Consumer.Pool.Size
 	if poolSize <= 0 {
 		poolSize = defaultPoolSize
 	}
 
+	ordered := isOrdered(c.config.Options.Consumer.Ordering)
+	shared := make(chan job)
+
+	queues := []chan job{shared}
+	if ordered {
+		queues = make([]chan job, 0, poolSize)
+	}
+
 	for range poolSize {
+		jobs := shared
+		if ordered {
+			jobs = make(chan job)
+			queues = append(queues, jobs)
+		}
+
 		c.wg.Add(1)
 		go c.startWorker(ctx, jobs, handler)
 	}
+
+	return queues
 }
 
 func (c *Consumer) startWorker(ctx context.Context, jobs chan job, handler func(context.Context, api.Envelope) error) {
@@ -150,7 +252,7 @@ func (c *Consumer) startWorker(ctx context.Context, jobs chan job, handler func(
 	}
 }
 
-func (c *Consumer) fetchMessages(ctx context.Context, r *kafka.Reader, jobs chan job) {
+func (c *Consumer) fetchMessages(ctx context.Context, r *kafka.Reader, queues []chan job) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -167,10 +269,14 @@ func (c *Consumer) fetchMessages(ctx context.Context, r *kafka.Reader, jobs chan
 				continue
 			}
 
+			if err = c.offsets.track(ctx, r, msg); err != nil {
+				return
+			}
+
 			select {
 			case <-ctx.Done():
 				return
-			case jobs <- job{r: r, msg: msg}:
+			case queues[queueIndex(c.config.Options.Consumer.Ordering, msg, len(queues))] <- job{r: r, msg: msg}:
 			}
 		}
 	}
@@ -184,20 +290,108 @@ func (c *Consumer) handleMessage(
 ) {
 	env, err := c.serializer.Unmarshal(msg.Value, c.headerMap(msg.Headers))
 	if err != nil {
-		c.commitMessage(ctx, r, msg)
+		c.deadLetter(ctx, r, msg, err)
 
 		return
 	}
 
-	env = env.WithStamp(stamps.ReceivedStamp{Transport: c.config.Name})
+	env = env.
+		WithStamp(stamps.ReceivedStamp{Transport: c.config.Name}).
+		WithStamp(originOf(msg))
 
-	if handlerErr := handler(ctx, env); handlerErr != nil {
-		c.commitMessage(ctx, r, msg)
+	c.deliver(ctx, r, msg, env, handler, 0)
+}
 
-		return
+// deliver redelivers a failed message that nobody took over after a backoff, because
+// kafka-go never fetches it again and its offset would hold back every later commit. It
+// retries on the calling worker, so an ordered partition or key waits for the message.
+func (c *Consumer) deliver(
+	ctx context.Context,
+	r *kafka.Reader,
+	msg kafka.Message,
+	env api.Envelope,
+	handler func(context.Context, api.Envelope) error,
+	attempt int,
+) {
+	for c.attempt(ctx, r, msg, env, handler, attempt) {
+		attempt++
+
+		if !sleepContext(ctx, redeliverDelay(attempt)) {
+			return
+		}
 	}
+}
 
-	c.commitMessage(ctx, r, msg)
+// attempt handles the message once and reports whether it has to be redelivered. A
+// deferred acknowledgement settles after the worker moved on, so its redelivery runs in
+// the background and is tracked by the wait group.
+func (c *Consumer) attempt(
+	ctx context.Context,
+	r *kafka.Reader,
+	msg kafka.Message,
+	env api.Envelope,
+	handler func(context.Context, api.Envelope) error,
+	attempt int,
+) bool {
+	redeliver := false
+
+	var acknowledger *ack.Acknowledger
+	acknowledger = ack.New(func(err error) {
+		switch {
+		case err == nil || errors.Is(err, api.ErrMessageHandedOff):
+			c.complete(ctx, r, msg)
+		case acknowledger.Deferred():
+			c.wg.Add(1)
+			go func() {
+				defer c.wg.Done()
+
+				if sleepContext(ctx, redeliverDelay(attempt+1)) {
+					c.deliver(ctx, r, msg, env, handler, attempt+1)
+				}
+			}()
+		default:
+			redeliver = true
+		}
+	})
+
+	handlerErr := handler(ctx, env.WithStamp(stamps.AckStamp{Acknowledger: acknowledger}))
+	if !acknowledger.Deferred() {
+		acknowledger.Ack(handlerErr)
+	}
+
+	return redeliver
+}
+
+func (c *Consumer) deadLetter(ctx context.Context, r *kafka.Reader, msg kafka.Message, cause error) {
+	for attempt := 1; !c.deadLetterRaw(ctx, msg, cause); attempt++ {
+		if !sleepContext(ctx, redeliverDelay(attempt)) {
+			return
+		}
+	}
+
+	c.complete(ctx, r, msg)
+}
+
+func (c *Consumer) complete(ctx context.Context, r *kafka.Reader, msg kafka.Message) {
+	if committable, ok := c.offsets.complete(r, msg); ok {
+		c.commitMessage(context.WithoutCancel(ctx), r, committable)
+	}
+}
+
+func redeliverDelay(attempt int) time.Duration {
+	return min(errorBackoffDelay<<min(attempt, 10), maxRedeliverDelay)
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
 }
 
 func (c *Consumer) commitMessage(ctx context.Context, r *kafka.Reader, msg kafka.Message) {
@@ -0,0 +1,106 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/stamps"
+)
+
+type fakeSerializer struct{}
+
+func (fakeSerializer) Marshal(env api.Envelope) ([]byte, map[string]string, error) {
+	return []byte(fmt.Sprint(env.Message())), map[string]string{}, nil
+}
+
+func (fakeSerializer) Unmarshal(body []byte, _ map[string]string) (api.Envelope, error) {
+	return envelope.NewEnvelope(string(body)), nil
+}
+
+func TestConsumer_HandleMessage_RedeliversFailedMessage(t *testing.T) {
+	c := &Consumer{
+		config: TransportConfig{
+			Name: "kafka",
+			Options: OptionsConfig{
+				Consumer: ConsumerOptionsConfig{Commit: CommitConfig{Strategy: "deferred"}},
+			},
+		},
+		serializer: fakeSerializer{},
+		offsets:    newOffsetTracker(maxPendingOffsets),
+	}
+
+	r := &kafka.Reader{}
+	msg := kafka.Message{Topic: "orders", Partition: 0, Offset: 7, Value: []byte("order")}
+	require.NoError(t, c.offsets.track(t.Context(), r, msg))
+
+	var calls atomic.Int32
+	handler := func(_ context.Context, env api.Envelope) error {
+		assert.Equal(t, "order", env.Message())
+
+		if calls.Add(1) == 1 {
+			return errors.New("database unavailable")
+		}
+
+		return nil
+	}
+
+	c.handleMessage(t.Context(), r, msg, handler)
+
+	_, committed := c.deferredCommits.Load("orders-0-7")
+	assert.True(t, committed, "the worker redelivers before it moves on")
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Empty(t, c.offsets.partition(r, msg).pending)
+}
+
+func TestConsumer_HandleMessage_RedeliversDeferredFailureInBackground(t *testing.T) {
+	c := &Consumer{
+		config: TransportConfig{
+			Name: "kafka",
+			Options: OptionsConfig{
+				Consumer: ConsumerOptionsConfig{Commit: CommitConfig{Strategy: "deferred"}},
+			},
+		},
+		serializer: fakeSerializer{},
+		offsets:    newOffsetTracker(maxPendingOffsets),
+	}
+
+	r := &kafka.Reader{}
+	msg := kafka.Message{Topic: "orders", Partition: 0, Offset: 8, Value: []byte("order")}
+	require.NoError(t, c.offsets.track(t.Context(), r, msg))
+
+	var calls atomic.Int32
+	handler := func(_ context.Context, env api.Envelope) error {
+		ackStamp, _ := envelope.LastStampOf[stamps.AckStamp](env)
+		ackStamp.Acknowledger.Defer()
+
+		if calls.Add(1) == 1 {
+			ackStamp.Acknowledger.Ack(errors.New("batch failed"))
+		} else {
+			ackStamp.Acknowledger.Ack(nil)
+		}
+
+		return nil
+	}
+
+	c.handleMessage(t.Context(), r, msg, handler)
+	c.wg.Wait()
+
+	_, committed := c.deferredCommits.Load("orders-0-8")
+	assert.True(t, committed)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRedeliverDelay(t *testing.T) {
+	assert.Equal(t, 200*time.Millisecond, redeliverDelay(1))
+	assert.Equal(t, maxRedeliverDelay, redeliverDelay(50))
+}
@@ -0,0 +1,168 @@
+package kafka
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	delayTopicInfix  = ".delay."
+	delayUntilHeader = "x-delay-until"
+)
+
+var defaultDelayTiers = []time.Duration{
+	time.Second,
+	10 * time.Second,
+	time.Minute,
+	10 * time.Minute,
+	time.Hour,
+}
+
+func delayTopicName(topic string, tier time.Duration) string {
+	return topic + delayTopicInfix + formatDelayTier(tier)
+}
+
+func formatDelayTier(tier time.Duration) string {
+	switch {
+	case tier%time.Hour == 0:
+		return strconv.FormatInt(int64(tier/time.Hour), 10) + "h"
+	case tier%time.Minute == 0:
+		return strconv.FormatInt(int64(tier/time.Minute), 10) + "m"
+	case tier%time.Second == 0:
+		return strconv.FormatInt(int64(tier/time.Second), 10) + "s"
+	default:
+		return strconv.FormatInt(tier.Milliseconds(), 10) + "ms"
+	}
+}
+
+func (c *TransportConfig) delayTiers() []time.Duration {
+	tiers := make([]time.Duration, 0, len(c.Options.DelayTopics.Tiers))
+	for _, tier := range c.Options.DelayTopics.Tiers {
+		if tier >= time.Millisecond {
+			tiers = append(tiers, tier.Truncate(time.Millisecond))
+		}
+	}
+
+	if len(tiers) == 0 {
+		return defaultDelayTiers
+	}
+
+	slices.Sort(tiers)
+
+	return slices.Compact(tiers)
+}
+
+// delayTier picks the longest tier that does not overshoot the delay. Every message of a
+// tier topic is held for the same time, so no message waits behind a longer one.
+func (c *TransportConfig) delayTier(delay time.Duration) time.Duration {
+	tiers := c.delayTiers()
+
+	tier := tiers[0]
+	for _, candidate := range tiers[1:] {
+		if candidate > delay {
+			break
+		}
+		tier = candidate
+	}
+
+	return tier
+}
+
+func withDelay(msg kafka.Message, delay time.Duration) kafka.Message {
+	headers := make([]kafka.Header, 0, len(msg.Headers)+1)
+	headers = append(headers, msg.Headers...)
+	headers = append(headers, kafka.Header{
+		Key:   delayUntilHeader,
+		Value: []byte(strconv.FormatInt(msg.Time.Add(delay).UnixMilli(), 10)),
+	})
+
+	msg.Headers = headers
+
+	return msg
+}
+
+func delayRemaining(msg kafka.Message) time.Duration {
+	for _, h := range msg.Headers {
+		if h.Key != delayUntilHeader {
+			continue
+		}
+
+		until, err := strconv.ParseInt(string(h.Value), 10, 64)
+		if err != nil {
+			return 0
+		}
+
+		return time.Until(time.UnixMilli(until))
+	}
+
+	return 0
+}
+
+func withoutDelay(msg kafka.Message) kafka.Message {
+	headers := make([]kafka.Header, 0, len(msg.Headers))
+	for _, h := range msg.Headers {
+		if h.Key != delayUntilHeader {
+			headers = append(headers, h)
+		}
+	}
+
+	return kafka.Message{
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+		Time:    time.Now(),
+	}
+}
+
+func stillDelayed(msg kafka.Message) kafka.Message {
+	return kafka.Message{
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: msg.Headers,
+		Time:    time.Now(),
+	}
+}
+
+// relayDelayed moves due messages of a delay or retry topic back to their topic. A tier
+// topic holds each message for at most its tier and hands a longer remaining delay on
+// to the next fitting tier; retry topics (hold 0) wait until the message is due.
+func (c *Consumer) relayDelayed(ctx context.Context, r *kafka.Reader, topic string, hold time.Duration) {
+	for {
+		msg, err := r.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			time.Sleep(errorBackoffDelay)
+
+			continue
+		}
+
+		wait := delayRemaining(msg)
+		if hold > 0 {
+			wait = min(wait, time.Until(msg.Time.Add(hold)))
+		}
+
+		if wait > 0 && !sleepContext(ctx, wait) {
+			return
+		}
+
+		next, out := topic, withoutDelay(msg)
+		if remaining := delayRemaining(msg); remaining > 0 {
+			next, out = delayTopicName(topic, c.config.delayTier(remaining)), stillDelayed(msg)
+		}
+
+		for c.delayWriters[next].WriteMessages(ctx, out) != nil {
+			if !sleepContext(ctx, errorBackoffDelay) {
+				return
+			}
+		}
+
+		_ = r.CommitMessages(ctx, msg)
+	}
+}
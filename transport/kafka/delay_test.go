@@ -0,0 +1,55 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelayTopics_Names(t *testing.T) {
+	assert.Equal(t, "orders.delay.1s", delayTopicName("orders", time.Second))
+	assert.Equal(t, "orders.delay.90s", delayTopicName("orders", 90*time.Second))
+	assert.Equal(t, "orders.delay.10m", delayTopicName("orders", 10*time.Minute))
+	assert.Equal(t, "orders.delay.2h", delayTopicName("orders", 2*time.Hour))
+	assert.Equal(t, "orders.delay.250ms", delayTopicName("orders", 250*time.Millisecond))
+}
+
+func TestDelayTopics_Tiers(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		cfg := TransportConfig{}
+
+		assert.Equal(t, defaultDelayTiers, cfg.delayTiers())
+	})
+
+	t.Run("sorts and drops invalid tiers", func(t *testing.T) {
+		cfg := TransportConfig{Options: OptionsConfig{DelayTopics: DelayTopicsConfig{
+			Tiers: []time.Duration{time.Minute, 0, time.Second, time.Minute},
+		}}}
+
+		assert.Equal(t, []time.Duration{time.Second, time.Minute}, cfg.delayTiers())
+	})
+
+	t.Run("picks the longest tier within the delay", func(t *testing.T) {
+		cfg := TransportConfig{}
+
+		assert.Equal(t, time.Second, cfg.delayTier(500*time.Millisecond))
+		assert.Equal(t, time.Second, cfg.delayTier(9*time.Second))
+		assert.Equal(t, 10*time.Second, cfg.delayTier(10*time.Second))
+		assert.Equal(t, 10*time.Minute, cfg.delayTier(45*time.Minute))
+		assert.Equal(t, time.Hour, cfg.delayTier(24*time.Hour))
+	})
+}
+
+func TestDelayTopics_Headers(t *testing.T) {
+	sent := time.Now()
+	msg := withDelay(kafka.Message{Time: sent, Headers: []kafka.Header{{Key: "type", Value: []byte("order")}}}, time.Minute)
+
+	assert.InDelta(t, time.Until(sent.Add(time.Minute)), delayRemaining(msg), float64(time.Millisecond))
+	assert.Equal(t, msg.Headers, stillDelayed(msg).Headers)
+
+	relayed := withoutDelay(msg)
+	assert.Equal(t, []kafka.Header{{Key: "type", Value: []byte("order")}}, relayed.Headers)
+	assert.Zero(t, delayRemaining(relayed))
+}
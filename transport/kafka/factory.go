@@ -17,7 +17,7 @@ func NewTransportFactory() api.TransportFactory {
 }
 
 func (t *TransportFactory) Supports(dsn string) bool {
-	return strings.HasPrefix(dsn, "kafka://")
+	return strings.HasPrefix(dsn, "kafka://") || strings.HasPrefix(dsn, "kafkas://")
 }
 
 func (t *TransportFactory) Create(name string, dsn string, options []byte, ser api.Serializer) (api.Transport, error) {
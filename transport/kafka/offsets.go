@@ -0,0 +1,130 @@
+package kafka
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const maxPendingOffsets = 10000
+
+type partitionKey struct {
+	reader    *kafka.Reader
+	topic     string
+	partition int
+}
+
+type partitionOffsets struct {
+	pending []int64
+	done    map[int64]kafka.Message
+	freed   chan struct{}
+}
+
+type offsetTracker struct {
+	mu         sync.Mutex
+	limit      int
+	partitions map[partitionKey]*partitionOffsets
+}
+
+func newOffsetTracker(limit int) *offsetTracker {
+	return &offsetTracker{
+		limit:      limit,
+		partitions: make(map[partitionKey]*partitionOffsets),
+	}
+}
+
+// track registers a fetched offset. It blocks while the partition already has limit
+// uncommitted offsets, which pauses fetching until the oldest of them completes.
+func (t *offsetTracker) track(ctx context.Context, r *kafka.Reader, msg kafka.Message) error {
+	for {
+		t.mu.Lock()
+		p := t.partition(r, msg)
+
+		if p.isPending(msg.Offset) {
+			t.mu.Unlock()
+
+			return nil
+		}
+
+		if len(p.pending) < t.limit {
+			idx := sort.Search(len(p.pending), func(i int) bool {
+				return p.pending[i] >= msg.Offset
+			})
+			p.pending = append(p.pending, 0)
+			copy(p.pending[idx+1:], p.pending[idx:])
+			p.pending[idx] = msg.Offset
+			t.mu.Unlock()
+
+			return nil
+		}
+
+		freed := p.freed
+		t.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-freed:
+		}
+	}
+}
+
+func (t *offsetTracker) complete(r *kafka.Reader, msg kafka.Message) (kafka.Message, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.partition(r, msg)
+	if !p.isPending(msg.Offset) {
+		return kafka.Message{}, false
+	}
+
+	p.done[msg.Offset] = msg
+
+	var (
+		committable kafka.Message
+		ok          bool
+	)
+
+	for len(p.pending) > 0 {
+		doneMsg, isDone := p.done[p.pending[0]]
+		if !isDone {
+			break
+		}
+
+		delete(p.done, p.pending[0])
+		p.pending = p.pending[1:]
+		committable, ok = doneMsg, true
+	}
+
+	if ok {
+		close(p.freed)
+		p.freed = make(chan struct{})
+	}
+
+	return committable, ok
+}
+
+func (p *partitionOffsets) isPending(offset int64) bool {
+	idx := sort.Search(len(p.pending), func(i int) bool {
+		return p.pending[i] >= offset
+	})
+
+	return idx < len(p.pending) && p.pending[idx] == offset
+}
+
+func (t *offsetTracker) partition(r *kafka.Reader, msg kafka.Message) *partitionOffsets {
+	key := partitionKey{reader: r, topic: msg.Topic, partition: msg.Partition}
+
+	p, ok := t.partitions[key]
+	if !ok {
+		p = &partitionOffsets{
+			done:  make(map[int64]kafka.Message),
+			freed: make(chan struct{}),
+		}
+		t.partitions[key] = p
+	}
+
+	return p
+}
@@ -0,0 +1,115 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOffsetTracker_Complete(t *testing.T) {
+	r := &kafka.Reader{}
+	msg := func(partition int, offset int64) kafka.Message {
+		return kafka.Message{Topic: "orders", Partition: partition, Offset: offset}
+	}
+
+	t.Run("commits only contiguous completed offsets", func(t *testing.T) {
+		tracker := newOffsetTracker(maxPendingOffsets)
+		for offset := range int64(3) {
+			require.NoError(t, tracker.track(t.Context(), r, msg(0, offset)))
+		}
+
+		_, ok := tracker.complete(r, msg(0, 1))
+		assert.False(t, ok, "offset 0 is still in flight")
+
+		committable, ok := tracker.complete(r, msg(0, 0))
+		require.True(t, ok)
+		assert.Equal(t, int64(1), committable.Offset)
+
+		committable, ok = tracker.complete(r, msg(0, 2))
+		require.True(t, ok)
+		assert.Equal(t, int64(2), committable.Offset)
+	})
+
+	t.Run("never commits past an uncompleted offset", func(t *testing.T) {
+		tracker := newOffsetTracker(maxPendingOffsets)
+		for offset := range int64(3) {
+			require.NoError(t, tracker.track(t.Context(), r, msg(0, offset)))
+		}
+
+		_, ok := tracker.complete(r, msg(0, 1))
+		assert.False(t, ok)
+
+		_, ok = tracker.complete(r, msg(0, 2))
+		assert.False(t, ok)
+	})
+
+	t.Run("tracks partitions independently", func(t *testing.T) {
+		tracker := newOffsetTracker(maxPendingOffsets)
+		require.NoError(t, tracker.track(t.Context(), r, msg(0, 10)))
+		require.NoError(t, tracker.track(t.Context(), r, msg(1, 20)))
+
+		committable, ok := tracker.complete(r, msg(1, 20))
+		require.True(t, ok)
+		assert.Equal(t, 1, committable.Partition)
+		assert.Equal(t, int64(20), committable.Offset)
+	})
+}
+
+func TestOffsetTracker_Track(t *testing.T) {
+	r := &kafka.Reader{}
+	msg := func(offset int64) kafka.Message {
+		return kafka.Message{Topic: "orders", Partition: 0, Offset: offset}
+	}
+
+	t.Run("pauses the partition at the pending limit", func(t *testing.T) {
+		tracker := newOffsetTracker(2)
+		require.NoError(t, tracker.track(t.Context(), r, msg(0)))
+		require.NoError(t, tracker.track(t.Context(), r, msg(1)))
+
+		tracked := make(chan error, 1)
+		go func() {
+			tracked <- tracker.track(t.Context(), r, msg(2))
+		}()
+
+		_, ok := tracker.complete(r, msg(1))
+		require.False(t, ok)
+
+		select {
+		case <-tracked:
+			t.Fatal("offset 2 was tracked while the partition was full")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		_, ok = tracker.complete(r, msg(0))
+		require.True(t, ok)
+
+		select {
+		case err := <-tracked:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("offset 2 was not tracked after the partition drained")
+		}
+	})
+
+	t.Run("stops waiting when the context ends", func(t *testing.T) {
+		tracker := newOffsetTracker(1)
+		require.NoError(t, tracker.track(t.Context(), r, msg(0)))
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		require.ErrorIs(t, tracker.track(ctx, r, msg(1)), context.Canceled)
+	})
+
+	t.Run("ignores offsets that are not tracked", func(t *testing.T) {
+		tracker := newOffsetTracker(1)
+
+		_, ok := tracker.complete(r, msg(5))
+		assert.False(t, ok)
+		assert.Empty(t, tracker.partition(r, msg(5)).done)
+	})
+}
@@ -0,0 +1,43 @@
+package kafka
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	orderingNone      = "none"
+	orderingPartition = "partition"
+	orderingKey       = "key"
+)
+
+func validateOrdering(ordering string) error {
+	switch ordering {
+	case "", orderingNone, orderingPartition, orderingKey:
+		return nil
+	default:
+		return fmt.Errorf("unknown consumer ordering %q, expected none, partition or key", ordering)
+	}
+}
+
+func isOrdered(ordering string) bool {
+	return ordering == orderingPartition || ordering == orderingKey
+}
+
+func queueIndex(ordering string, msg kafka.Message, queues int) int {
+	if queues <= 1 || !isOrdered(ordering) {
+		return 0
+	}
+
+	h := fnv.New32a()
+	if ordering == orderingKey && len(msg.Key) > 0 {
+		_, _ = h.Write(msg.Key)
+	} else {
+		_, _ = h.Write([]byte(msg.Topic + "/" + strconv.Itoa(msg.Partition)))
+	}
+
+	return int(h.Sum32() % uint32(queues)) //nolint:gosec // queues is a positive pool size
+}
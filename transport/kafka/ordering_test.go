@@ -0,0 +1,58 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueueIndex(t *testing.T) {
+	t.Run("without ordering everything goes to the shared queue", func(t *testing.T) {
+		msg := kafka.Message{Topic: "orders", Partition: 3, Key: []byte("a")}
+
+		assert.Equal(t, 0, queueIndex("none", msg, 1))
+		assert.Equal(t, 0, queueIndex("", msg, 5))
+	})
+
+	t.Run("partition ordering pins a partition to one queue", func(t *testing.T) {
+		first := kafka.Message{Topic: "orders", Partition: 2, Key: []byte("a"), Offset: 1}
+		second := kafka.Message{Topic: "orders", Partition: 2, Key: []byte("b"), Offset: 2}
+
+		assert.Equal(t, queueIndex("partition", first, 4), queueIndex("partition", second, 4))
+	})
+
+	t.Run("partition ordering spreads partitions across queues", func(t *testing.T) {
+		used := make(map[int]struct{})
+		for partition := range 16 {
+			idx := queueIndex("partition", kafka.Message{Topic: "orders", Partition: partition}, 4)
+			assert.GreaterOrEqual(t, idx, 0)
+			assert.Less(t, idx, 4)
+			used[idx] = struct{}{}
+		}
+
+		assert.Greater(t, len(used), 1)
+	})
+
+	t.Run("key ordering pins a key to one queue across partitions", func(t *testing.T) {
+		first := kafka.Message{Topic: "orders", Partition: 0, Key: []byte("customer-1")}
+		second := kafka.Message{Topic: "orders", Partition: 5, Key: []byte("customer-1")}
+
+		assert.Equal(t, queueIndex("key", first, 8), queueIndex("key", second, 8))
+	})
+
+	t.Run("key ordering falls back to the partition for empty keys", func(t *testing.T) {
+		keyless := kafka.Message{Topic: "orders", Partition: 1}
+
+		assert.Equal(t, queueIndex("partition", keyless, 8), queueIndex("key", keyless, 8))
+	})
+}
+
+func TestValidateOrdering(t *testing.T) {
+	for _, ordering := range []string{"", "none", "partition", "key"} {
+		assert.NoError(t, validateOrdering(ordering), ordering)
+	}
+
+	assert.EqualError(t, validateOrdering("partitions"),
+		`unknown consumer ordering "partitions", expected none, partition or key`)
+}
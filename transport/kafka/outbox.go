@@ -0,0 +1,123 @@
+package kafka
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	outboxTopicSuffix   = ".outbox"
+	outboxTopicsHeader  = "x-outbox-topics"
+	outboxTopicsDivider = ","
+)
+
+func (c *TransportConfig) outboxEnabled() bool {
+	return c.Options.Producer.Outbox.Enabled && c.outboxTopic() != ""
+}
+
+func (c *TransportConfig) outboxTopic() string {
+	if topic := c.Options.Producer.Outbox.Topic; topic != "" {
+		return topic
+	}
+
+	if len(c.Options.Topics) == 0 {
+		return ""
+	}
+
+	return c.Options.Topics[0] + outboxTopicSuffix
+}
+
+// toOutbox wraps a message bound for several topics into a single outbox record, so the
+// producer either writes all of them or none.
+func toOutbox(msg kafka.Message, topics []string) kafka.Message {
+	headers := make([]kafka.Header, 0, len(msg.Headers)+1)
+	headers = append(headers, msg.Headers...)
+	headers = append(headers, kafka.Header{
+		Key:   outboxTopicsHeader,
+		Value: []byte(strings.Join(topics, outboxTopicsDivider)),
+	})
+
+	msg.Headers = headers
+
+	return msg
+}
+
+// fromOutbox unwraps an outbox record into one message per target topic.
+func fromOutbox(msg kafka.Message) []kafka.Message {
+	var topics []string
+
+	headers := make([]kafka.Header, 0, len(msg.Headers))
+	for _, h := range msg.Headers {
+		if h.Key == outboxTopicsHeader {
+			topics = strings.Split(string(h.Value), outboxTopicsDivider)
+
+			continue
+		}
+
+		headers = append(headers, h)
+	}
+
+	messages := make([]kafka.Message, 0, len(topics))
+	for _, topic := range topics {
+		if topic == "" {
+			continue
+		}
+
+		messages = append(messages, kafka.Message{
+			Topic:   topic,
+			Key:     msg.Key,
+			Value:   msg.Value,
+			Headers: headers,
+			Time:    time.Now(),
+		})
+	}
+
+	return messages
+}
+
+func (c *Consumer) startOutboxRelay(ctx context.Context) {
+	c.outboxWriter = c.connection.CreateWriter("", c.config.Options.Producer, false, &kafka.Hash{})
+
+	readerConfig := c.readerConfig(c.config.outboxTopic())
+	readerConfig.StartOffset = kafka.FirstOffset
+
+	reader := c.connection.CreateReader(readerConfig)
+	c.delayReaders = append(c.delayReaders, reader)
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.relayOutbox(ctx, reader)
+	}()
+}
+
+// relayOutbox hands outbox records on to their topics and commits a record only once every
+// topic took it. A crash in between relays the record again, so targets may see duplicates
+// but never miss a part of it.
+func (c *Consumer) relayOutbox(ctx context.Context, r *kafka.Reader) {
+	for {
+		msg, err := r.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			time.Sleep(errorBackoffDelay)
+
+			continue
+		}
+
+		if messages := fromOutbox(msg); len(messages) > 0 {
+			for c.outboxWriter.WriteMessages(ctx, messages...) != nil {
+				if !sleepContext(ctx, errorBackoffDelay) {
+					return
+				}
+			}
+		}
+
+		_ = r.CommitMessages(ctx, msg)
+	}
+}
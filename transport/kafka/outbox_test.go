@@ -0,0 +1,45 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutbox_Topic(t *testing.T) {
+	cfg := TransportConfig{Options: OptionsConfig{Topics: []string{"ledger", "audit"}}}
+	assert.False(t, cfg.outboxEnabled())
+
+	cfg.Options.Producer.Outbox.Enabled = true
+	assert.True(t, cfg.outboxEnabled())
+	assert.Equal(t, "ledger.outbox", cfg.outboxTopic())
+
+	cfg.Options.Producer.Outbox.Topic = "ledger-outbox"
+	assert.Equal(t, "ledger-outbox", cfg.outboxTopic())
+	assert.Contains(t, cfg.setupTopicNames(), "ledger-outbox")
+}
+
+func TestOutbox_RoundTrip(t *testing.T) {
+	msg := kafka.Message{
+		Key:     []byte("entry-1"),
+		Value:   []byte("payload"),
+		Headers: []kafka.Header{{Key: "type", Value: []byte("entry")}},
+	}
+
+	wrapped := toOutbox(msg, []string{"ledger", "audit.delay.1m"})
+	assert.Len(t, wrapped.Headers, 2)
+
+	messages := fromOutbox(wrapped)
+	require.Len(t, messages, 2)
+
+	for i, topic := range []string{"ledger", "audit.delay.1m"} {
+		assert.Equal(t, topic, messages[i].Topic)
+		assert.Equal(t, msg.Key, messages[i].Key)
+		assert.Equal(t, msg.Value, messages[i].Value)
+		assert.Equal(t, msg.Headers, messages[i].Headers)
+	}
+
+	assert.Empty(t, fromOutbox(msg))
+}
@@ -4,29 +4,39 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/segmentio/kafka-go"
 
 	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
 	"github.com/gerfey/messenger/core/stamps"
 )
 
+var ErrUnknownTopic = errors.New("kafka: topic is neither configured nor listed in topic.routes")
+
 type Producer struct {
 	config     TransportConfig
 	serializer api.Serializer
 	connection ConnectionKafka
 	writers    map[string]*kafka.Writer
 	mu         sync.RWMutex
+	replies    *replies
 }
 
-func NewProducer(config TransportConfig, connection ConnectionKafka, serializer api.Serializer) (api.Producer, error) {
+func NewProducer(
+	config TransportConfig,
+	connection ConnectionKafka,
+	serializer api.Serializer,
+) (api.RequestReplyProducer, error) {
 	p := &Producer{
 		config:     config,
 		connection: connection,
 		serializer: serializer,
 		writers:    make(map[string]*kafka.Writer),
+		replies:    newReplies(),
 	}
 
 	if len(config.Options.Topics) == 0 {
@@ -47,15 +57,29 @@ func NewProducer(config TransportConfig, connection ConnectionKafka, serializer
 		balancer = &kafka.Hash{}
 	}
 
-	for _, topic := range config.Options.Topics {
-		writer := connection.CreateWriter(
-			topic,
+	for _, topic := range config.routableTopics() {
+		names := append([]string{topic}, config.relayTopics(topic)...)
+		if config.Options.RetryTopics.Enabled {
+			names = append(names, deadLetterTopicName(topic))
+		}
+
+		for _, name := range names {
+			p.writers[name] = connection.CreateWriter(
+				name,
+				config.Options.Producer,
+				config.Options.Producer.Async,
+				balancer,
+			)
+		}
+	}
+
+	if config.outboxEnabled() {
+		p.writers[config.outboxTopic()] = connection.CreateWriter(
+			config.outboxTopic(),
 			config.Options.Producer,
 			config.Options.Producer.Async,
 			balancer,
 		)
-
-		p.writers[topic] = writer
 	}
 
 	return p, nil
@@ -83,28 +107,91 @@ func (p *Producer) Send(ctx context.Context, env api.Envelope) error {
 		msg.Key = key
 	}
 
-	p.mu.RLock()
-	defer p.mu.RUnlock()
+	delayStamp, delayed := envelope.LastStampOf[stamps.DelayStamp](env)
+	delayed = delayed && delayStamp.Milliseconds > 0
+	if delayed {
+		msg = withDelay(msg, delayStamp.Duration())
+	}
+
+	retryLevel, retrying := p.retryLevel(env)
+	deadLetter := p.isDeadLetter(env)
+
+	if retrying || deadLetter {
+		msg.Headers = append(msg.Headers, originHeaders(env)...)
+	}
+
+	targets, err := p.targetTopics(env)
+	if err != nil {
+		return err
+	}
 
-	for _, topic := range p.config.Options.Topics {
-		writer, exists := p.writers[topic]
-		if !exists {
-			return fmt.Errorf("writer for topic %s not found", topic)
+	topics := make([]string, 0, len(targets))
+	for _, target := range targets {
+		switch {
+		case deadLetter:
+			topics = append(topics, deadLetterTopicName(target))
+		case retrying:
+			topics = append(topics, retryTopicName(target, retryLevel))
+		case delayed:
+			topics = append(topics, delayTopicName(target, p.config.delayTier(delayStamp.Duration())))
+		default:
+			topics = append(topics, target)
 		}
+	}
 
-		if writeErr := writer.WriteMessages(ctx, msg); writeErr != nil {
-			return fmt.Errorf("producer failed to write messages to topic %s: %w", topic, writeErr)
+	if len(topics) > 1 && p.config.outboxEnabled() {
+		return p.write(ctx, p.config.outboxTopic(), toOutbox(msg, topics))
+	}
+
+	for _, topic := range topics {
+		if err = p.write(ctx, topic, msg); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+func (p *Producer) write(ctx context.Context, topic string, msg kafka.Message) error {
+	p.mu.RLock()
+	writer, ok := p.writers[topic]
+	p.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownTopic, topic)
+	}
+
+	if err := writer.WriteMessages(ctx, msg); err != nil {
+		return fmt.Errorf("producer failed to write messages to topic %s: %w", topic, err)
+	}
+
+	return nil
+}
+
+func (p *Producer) targetTopics(env api.Envelope) ([]string, error) {
+	if origin, ok := envelope.LastStampOf[OriginStamp](env); ok {
+		if slices.Contains(p.config.routableTopics(), origin.Topic) {
+			return []string{origin.Topic}, nil
+		}
+	}
+
+	topic, ok := p.routedTopic(env)
+	if !ok {
+		return p.config.Options.Topics, nil
+	}
+
+	if !slices.Contains(p.config.routableTopics(), topic) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTopic, topic)
+	}
+
+	return []string{topic}, nil
+}
+
 func (p *Producer) Close() error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	var errs []error
+	errs := p.closeReplies()
 	for topic, writer := range p.writers {
 		if err := writer.Close(); err != nil {
 			errs = append(errs, fmt.Errorf("failed to close writer for topic %s: %w", topic, err))
@@ -117,17 +204,3 @@ func (p *Producer) Close() error {
 
 	return nil
 }
-
-func (p *Producer) extractMessageKey(env api.Envelope) ([]byte, error) {
-	if p.config.Options.Key.Strategy != "message_id" {
-		return nil, nil
-	}
-
-	for _, s := range env.Stamps() {
-		if msgIDStamp, ok := s.(stamps.MessageIDStamp); ok {
-			return []byte(msgIDStamp.MessageID), nil
-		}
-	}
-
-	return nil, errors.New("message_id stamp not found")
-}
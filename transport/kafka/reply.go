@@ -0,0 +1,225 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/rpc"
+	"github.com/gerfey/messenger/core/stamps"
+)
+
+const (
+	replyPartition = 0
+)
+
+var (
+	ErrNoReplyAddress    = errors.New("kafka: request has no reply topic")
+	ErrUnknownReplyTopic = errors.New("kafka: reply topic is not configured")
+)
+
+type replies struct {
+	pending *rpc.Pending
+	reader  *kafka.Reader
+	writers map[string]*kafka.Writer
+	mu      sync.Mutex
+}
+
+func newReplies() *replies {
+	return &replies{
+		pending: rpc.NewPending(),
+		writers: make(map[string]*kafka.Writer),
+	}
+}
+
+func (p *Producer) Request(ctx context.Context, env api.Envelope) (api.Envelope, error) {
+	topic := p.config.Options.Reply.Topic
+	if topic == "" {
+		return nil, fmt.Errorf("%w: reply.topic is not configured", rpc.ErrRequestNotSupported)
+	}
+
+	if err := p.startReplies(ctx, topic); err != nil {
+		return nil, err
+	}
+
+	requestStamp, _ := envelope.LastStampOf[stamps.RequestStamp](env)
+	env = env.WithStamp(stamps.RequestStamp{CorrelationID: requestStamp.CorrelationID, ReplyTo: topic})
+
+	return p.replies.pending.Await(ctx, requestStamp.CorrelationID, func() error {
+		return p.Send(ctx, env)
+	})
+}
+
+func (p *Producer) Reply(ctx context.Context, request api.Envelope, reply api.Envelope) error {
+	requestStamp, _ := envelope.LastStampOf[stamps.RequestStamp](request)
+	if requestStamp.ReplyTo == "" {
+		return ErrNoReplyAddress
+	}
+
+	if !p.config.Options.Reply.accepts(requestStamp.ReplyTo) {
+		return fmt.Errorf("%w: %s", ErrUnknownReplyTopic, requestStamp.ReplyTo)
+	}
+
+	msg, err := p.replyMessage(reply, requestStamp.CorrelationID)
+	if err != nil {
+		return err
+	}
+
+	if err = p.replyWriter(requestStamp.ReplyTo).WriteMessages(ctx, msg); err != nil {
+		return fmt.Errorf("producer failed to write reply to topic %s: %w", requestStamp.ReplyTo, err)
+	}
+
+	return nil
+}
+
+func (p *Producer) replyMessage(reply api.Envelope, correlationID string) (kafka.Message, error) {
+	payload, headers, err := p.serializer.Marshal(reply)
+	if err != nil {
+		return kafka.Message{}, fmt.Errorf("serializer reply failed: %w", err)
+	}
+
+	kHeaders := make([]kafka.Header, 0, len(headers))
+	for k, v := range headers {
+		kHeaders = append(kHeaders, kafka.Header{Key: k, Value: []byte(v)})
+	}
+
+	return kafka.Message{
+		Key:     []byte(correlationID),
+		Headers: kHeaders,
+		Value:   payload,
+	}, nil
+}
+
+func (p *Producer) startReplies(ctx context.Context, topic string) error {
+	p.replies.mu.Lock()
+	defer p.replies.mu.Unlock()
+
+	if p.replies.reader != nil {
+		return nil
+	}
+
+	res, err := p.connection.Admin().ListOffsets(ctx, &kafka.ListOffsetsRequest{
+		Topics: map[string][]kafka.OffsetRequest{topic: {kafka.LastOffsetOf(replyPartition)}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resolve offset of reply topic %s: %w", topic, err)
+	}
+
+	offsets := res.Topics[topic]
+	if len(offsets) == 0 {
+		return fmt.Errorf("reply topic %s has no partitions", topic)
+	}
+
+	if offsets[0].Error != nil {
+		return fmt.Errorf("failed to resolve offset of reply topic %s: %w", topic, offsets[0].Error)
+	}
+
+	reader := p.connection.CreateReader(kafka.ReaderConfig{
+		Topic:     topic,
+		Partition: replyPartition,
+		MinBytes:  1,
+		MaxBytes:  maxBytes,
+	})
+
+	if err = reader.SetOffset(offsets[0].LastOffset); err != nil {
+		_ = reader.Close()
+
+		return fmt.Errorf("failed to position reply reader: %w", err)
+	}
+
+	p.replies.reader = reader
+
+	go p.receiveReplies(reader)
+
+	return nil
+}
+
+func (p *Producer) receiveReplies(reader *kafka.Reader) {
+	for {
+		msg, err := reader.ReadMessage(context.Background())
+		if err != nil {
+			p.resetReplies(reader)
+
+			return
+		}
+
+		p.resolveReply(msg)
+	}
+}
+
+func (p *Producer) resolveReply(msg kafka.Message) {
+	headers := make(map[string]string, len(msg.Headers))
+	for _, h := range msg.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+
+	reply, err := p.serializer.Unmarshal(msg.Value, headers)
+	if err != nil {
+		p.replies.pending.Reject(string(msg.Key), fmt.Errorf("failed to decode reply: %w", err))
+
+		return
+	}
+
+	p.replies.pending.Resolve(reply)
+}
+
+// resetReplies drops a failed reply reader so that the next request starts a new one.
+func (p *Producer) resetReplies(reader *kafka.Reader) {
+	p.replies.mu.Lock()
+	defer p.replies.mu.Unlock()
+
+	if p.replies.reader != reader {
+		return
+	}
+
+	p.replies.reader = nil
+	_ = reader.Close()
+}
+
+func (p *Producer) replyWriter(topic string) *kafka.Writer {
+	p.replies.mu.Lock()
+	defer p.replies.mu.Unlock()
+
+	if writer, ok := p.replies.writers[topic]; ok {
+		return writer
+	}
+
+	writer := p.connection.CreateWriter(
+		topic,
+		p.config.Options.Producer,
+		false,
+		kafka.BalancerFunc(func(_ kafka.Message, partitions ...int) int {
+			return partitions[replyPartition]
+		}),
+	)
+	p.replies.writers[topic] = writer
+
+	return writer
+}
+
+func (p *Producer) closeReplies() []error {
+	p.replies.mu.Lock()
+	defer p.replies.mu.Unlock()
+
+	var errs []error
+
+	if p.replies.reader != nil {
+		if err := p.replies.reader.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close reply reader: %w", err))
+		}
+		p.replies.reader = nil
+	}
+
+	for topic, writer := range p.replies.writers {
+		if err := writer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close reply writer for topic %s: %w", topic, err))
+		}
+	}
+
+	return errs
+}
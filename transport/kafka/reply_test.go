@@ -0,0 +1,80 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/rpc"
+	"github.com/gerfey/messenger/core/stamps"
+	"github.com/gerfey/messenger/tests/helpers"
+)
+
+func TestProducer_ReplyRoundTrip(t *testing.T) {
+	worker := &Producer{serializer: helpers.NewTestSerializer(&helpers.TestMessage{})}
+
+	request := envelope.NewEnvelope(&helpers.TestMessage{Content: "ping"}).
+		WithStamp(stamps.RequestStamp{CorrelationID: "42"})
+	handled := request.WithStamp(stamps.HandledStamp{Handler: "handler", Result: &helpers.TestMessage{Content: "pong"}})
+
+	msg, err := worker.replyMessage(rpc.NewReply(request, handled, nil), "42")
+	require.NoError(t, err)
+
+	t.Run("decodes registered reply type", func(t *testing.T) {
+		requester := &Producer{serializer: helpers.NewTestSerializer(&helpers.TestMessage{}), replies: newReplies()}
+
+		reply, err := requester.replies.pending.Await(t.Context(), "42", func() error {
+			requester.resolveReply(msg)
+
+			return nil
+		})
+		require.NoError(t, err)
+
+		result, err := rpc.Result(reply)
+		require.NoError(t, err)
+		assert.Equal(t, &helpers.TestMessage{Content: "pong"}, result.Result)
+	})
+
+	t.Run("fails the request when the reply type is unknown", func(t *testing.T) {
+		requester := &Producer{serializer: helpers.NewTestSerializer(), replies: newReplies()}
+
+		_, err := requester.replies.pending.Await(t.Context(), "42", func() error {
+			requester.resolveReply(msg)
+
+			return nil
+		})
+
+		require.ErrorContains(t, err, "unknown message type: *helpers.TestMessage")
+	})
+}
+
+func TestProducer_Reply_UnknownTopic(t *testing.T) {
+	p := &Producer{
+		config:     TransportConfig{Options: OptionsConfig{Reply: ReplyConfig{Topic: "orders.replies"}}},
+		serializer: helpers.NewTestSerializer(&helpers.TestMessage{}),
+		replies:    newReplies(),
+	}
+
+	request := envelope.NewEnvelope(&helpers.TestMessage{}).
+		WithStamp(stamps.RequestStamp{CorrelationID: "42", ReplyTo: "__consumer_offsets"})
+
+	err := p.Reply(t.Context(), request, envelope.NewEnvelope(&helpers.TestMessage{}))
+
+	require.ErrorIs(t, err, ErrUnknownReplyTopic)
+	assert.Empty(t, p.replies.writers)
+}
+
+func TestProducer_ReceiveReplies_ResetsFailedReader(t *testing.T) {
+	reader := kafka.NewReader(kafka.ReaderConfig{Brokers: []string{"127.0.0.1:1"}, Topic: "orders.replies"})
+	require.NoError(t, reader.Close())
+
+	p := &Producer{replies: newReplies()}
+	p.replies.reader = reader
+
+	p.receiveReplies(reader)
+
+	assert.Nil(t, p.replies.reader)
+}
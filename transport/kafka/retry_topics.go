@@ -0,0 +1,146 @@
+package kafka
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/stamps"
+)
+
+const (
+	retryTopicInfix         = ".retry."
+	deadLetterTopicSuffix   = ".dlq"
+	originalTopicHeader     = "x-original-topic"
+	originalPartitionHeader = "x-original-partition"
+	originalOffsetHeader    = "x-original-offset"
+	errorHeader             = "x-error"
+)
+
+type OriginStamp struct {
+	Topic     string
+	Partition int
+	Offset    int64
+}
+
+func retryTopicName(topic string, level int) string {
+	return topic + retryTopicInfix + strconv.Itoa(level)
+}
+
+func deadLetterTopicName(topic string) string {
+	return topic + deadLetterTopicSuffix
+}
+
+func originOf(msg kafka.Message) OriginStamp {
+	origin := OriginStamp{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+	}
+
+	for _, h := range msg.Headers {
+		switch h.Key {
+		case originalTopicHeader:
+			origin.Topic = string(h.Value)
+		case originalPartitionHeader:
+			if partition, err := strconv.Atoi(string(h.Value)); err == nil {
+				origin.Partition = partition
+			}
+		case originalOffsetHeader:
+			if offset, err := strconv.ParseInt(string(h.Value), 10, 64); err == nil {
+				origin.Offset = offset
+			}
+		}
+	}
+
+	return origin
+}
+
+func originHeaders(env api.Envelope) []kafka.Header {
+	headers := make([]kafka.Header, 0)
+
+	if origin, ok := envelope.LastStampOf[OriginStamp](env); ok {
+		headers = append(headers,
+			kafka.Header{Key: originalTopicHeader, Value: []byte(origin.Topic)},
+			kafka.Header{Key: originalPartitionHeader, Value: []byte(strconv.Itoa(origin.Partition))},
+			kafka.Header{Key: originalOffsetHeader, Value: []byte(strconv.FormatInt(origin.Offset, 10))},
+		)
+	}
+
+	if errorStamp, ok := envelope.LastStampOf[stamps.ErrorDetailsStamp](env); ok {
+		headers = append(headers, kafka.Header{Key: errorHeader, Value: []byte(errorStamp.ErrorMessage)})
+	}
+
+	return headers
+}
+
+func (p *Producer) retryLevel(env api.Envelope) (int, bool) {
+	if !p.config.Options.RetryTopics.Enabled {
+		return 0, false
+	}
+
+	redelivery, ok := envelope.LastStampOf[stamps.RedeliveryStamp](env)
+	if !ok {
+		return 0, false
+	}
+
+	return min(int(redelivery.RetryCount)+1, p.config.retryLevels()), true //nolint:gosec // retry count is small
+}
+
+func (p *Producer) isDeadLetter(env api.Envelope) bool {
+	if !p.config.Options.RetryTopics.Enabled {
+		return false
+	}
+
+	failedStamp, ok := envelope.LastStampOf[stamps.SentToFailureTransportStamp](env)
+
+	return ok && failedStamp.OriginalTransport == p.config.Name
+}
+
+func (c *TransportConfig) relayTopics(topic string) []string {
+	topics := make([]string, 0, len(c.delayTiers())+c.retryLevels())
+	for _, tier := range c.delayTiers() {
+		topics = append(topics, delayTopicName(topic, tier))
+	}
+
+	if c.Options.RetryTopics.Enabled {
+		for level := 1; level <= c.retryLevels(); level++ {
+			topics = append(topics, retryTopicName(topic, level))
+		}
+	}
+
+	return topics
+}
+
+func (c *TransportConfig) retryLevels() int {
+	return max(c.Options.RetryTopics.Levels, 1)
+}
+
+func (c *Consumer) deadLetterRaw(ctx context.Context, msg kafka.Message, cause error) bool {
+	writer, ok := c.deadLetters[msg.Topic]
+	if !ok {
+		return false
+	}
+
+	origin := originOf(msg)
+
+	headers := make([]kafka.Header, 0, len(msg.Headers))
+	headers = append(headers, msg.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: originalTopicHeader, Value: []byte(origin.Topic)},
+		kafka.Header{Key: originalPartitionHeader, Value: []byte(strconv.Itoa(origin.Partition))},
+		kafka.Header{Key: originalOffsetHeader, Value: []byte(strconv.FormatInt(origin.Offset, 10))},
+		kafka.Header{Key: errorHeader, Value: []byte(cause.Error())},
+	)
+
+	err := writer.WriteMessages(ctx, kafka.Message{
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	})
+
+	return err == nil
+}
@@ -0,0 +1,112 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/stamps"
+	"github.com/gerfey/messenger/tests/helpers"
+)
+
+func newRetryTopicsProducer(levels int) *Producer {
+	return &Producer{
+		config: TransportConfig{
+			Name: "orders",
+			Options: OptionsConfig{
+				Topics:      []string{"orders", "payments"},
+				RetryTopics: RetryTopicsConfig{Enabled: true, Levels: levels},
+			},
+		},
+	}
+}
+
+func TestRetryTopics_Names(t *testing.T) {
+	assert.Equal(t, "orders.retry.2", retryTopicName("orders", 2))
+	assert.Equal(t, "orders.dlq", deadLetterTopicName("orders"))
+
+	cfg := TransportConfig{Options: OptionsConfig{
+		DelayTopics: DelayTopicsConfig{Tiers: []time.Duration{time.Minute}},
+		RetryTopics: RetryTopicsConfig{Enabled: true, Levels: 2},
+	}}
+	assert.Equal(t, []string{"orders.delay.1m", "orders.retry.1", "orders.retry.2"}, cfg.relayTopics("orders"))
+}
+
+func TestRetryTopics_Origin(t *testing.T) {
+	t.Run("uses message coordinates", func(t *testing.T) {
+		origin := originOf(kafka.Message{Topic: "orders", Partition: 2, Offset: 42})
+
+		assert.Equal(t, OriginStamp{Topic: "orders", Partition: 2, Offset: 42}, origin)
+	})
+
+	t.Run("keeps coordinates of the first delivery", func(t *testing.T) {
+		env := envelope.NewEnvelope(&helpers.TestMessage{}).
+			WithStamp(OriginStamp{Topic: "orders", Partition: 2, Offset: 42}).
+			WithStamp(stamps.ErrorDetailsStamp{ErrorMessage: "boom", FailedAt: time.Now()})
+
+		origin := originOf(kafka.Message{
+			Topic:     "orders",
+			Partition: 0,
+			Offset:    100,
+			Headers:   originHeaders(env),
+		})
+
+		assert.Equal(t, OriginStamp{Topic: "orders", Partition: 2, Offset: 42}, origin)
+		assert.Contains(t, originHeaders(env), kafka.Header{Key: errorHeader, Value: []byte("boom")})
+	})
+}
+
+func TestRetryTopics_Routing(t *testing.T) {
+	t.Run("retry level follows redelivery count and is capped", func(t *testing.T) {
+		p := newRetryTopicsProducer(2)
+
+		level, ok := p.retryLevel(envelope.NewEnvelope(&helpers.TestMessage{}).
+			WithStamp(stamps.RedeliveryStamp{RetryCount: 0}))
+		require.True(t, ok)
+		assert.Equal(t, 1, level)
+
+		level, ok = p.retryLevel(envelope.NewEnvelope(&helpers.TestMessage{}).
+			WithStamp(stamps.RedeliveryStamp{RetryCount: 5}))
+		require.True(t, ok)
+		assert.Equal(t, 2, level)
+
+		_, ok = p.retryLevel(envelope.NewEnvelope(&helpers.TestMessage{}))
+		assert.False(t, ok)
+	})
+
+	t.Run("dead letter only for own failures", func(t *testing.T) {
+		p := newRetryTopicsProducer(2)
+
+		assert.True(t, p.isDeadLetter(envelope.NewEnvelope(&helpers.TestMessage{}).
+			WithStamp(stamps.SentToFailureTransportStamp{OriginalTransport: "orders"})))
+		assert.False(t, p.isDeadLetter(envelope.NewEnvelope(&helpers.TestMessage{}).
+			WithStamp(stamps.SentToFailureTransportStamp{OriginalTransport: "other"})))
+	})
+
+	t.Run("targets the topic the message came from", func(t *testing.T) {
+		p := newRetryTopicsProducer(2)
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{}).WithStamp(OriginStamp{Topic: "payments"})
+		topics, err := p.targetTopics(env)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"payments"}, topics)
+
+		topics, err = p.targetTopics(envelope.NewEnvelope(&helpers.TestMessage{}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"orders", "payments"}, topics)
+	})
+
+	t.Run("targets the origin topic without retry topics", func(t *testing.T) {
+		p := newRetryTopicsProducer(2)
+		p.config.Options.RetryTopics.Enabled = false
+
+		env := envelope.NewEnvelope(&helpers.TestMessage{}).WithStamp(OriginStamp{Topic: "payments"})
+		topics, err := p.targetTopics(env)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"payments"}, topics)
+	})
+}
@@ -0,0 +1,61 @@
+package kafka
+
+import (
+	"errors"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/stamps"
+)
+
+const (
+	keyStrategyMessageID    = "message_id"
+	keyStrategyRoutingKey   = "routing_key"
+	keyStrategyPartitionKey = "partition_key"
+	topicStrategyRoutingKey = "routing_key"
+)
+
+func (p *Producer) routedTopic(env api.Envelope) (string, bool) {
+	if stamp, ok := envelope.LastStampOf[stamps.TopicStamp](env); ok && stamp.Topic != "" {
+		return stamp.Topic, true
+	}
+
+	if p.config.Options.Topic.Strategy != topicStrategyRoutingKey {
+		return "", false
+	}
+
+	if stamp, ok := envelope.LastStampOf[stamps.RoutingKeyStamp](env); ok && stamp.Key != "" {
+		return stamp.Key, true
+	}
+
+	if routed, ok := env.Message().(api.RoutedMessage); ok && routed.RoutingKey() != "" {
+		return routed.RoutingKey(), true
+	}
+
+	return "", false
+}
+
+func (p *Producer) extractMessageKey(env api.Envelope) ([]byte, error) {
+	switch p.config.Options.Key.Strategy {
+	case keyStrategyMessageID:
+		if msgIDStamp, ok := envelope.FirstStampOf[stamps.MessageIDStamp](env); ok {
+			return []byte(msgIDStamp.MessageID), nil
+		}
+
+		return nil, errors.New("message_id stamp not found")
+	case keyStrategyRoutingKey:
+		if routed, ok := env.Message().(api.RoutedMessage); ok {
+			return []byte(routed.RoutingKey()), nil
+		}
+
+		return nil, errors.New("message does not implement api.RoutedMessage")
+	case keyStrategyPartitionKey:
+		if partitioned, ok := env.Message().(api.PartitionedMessage); ok {
+			return []byte(partitioned.PartitionKey()), nil
+		}
+
+		return nil, errors.New("message does not implement api.PartitionedMessage")
+	default:
+		return nil, nil
+	}
+}
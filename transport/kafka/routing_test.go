@@ -0,0 +1,135 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/stamps"
+	"github.com/gerfey/messenger/tests/helpers"
+)
+
+type orderPlaced struct {
+	OrderID    string
+	CustomerID string
+}
+
+func (m *orderPlaced) RoutingKey() string {
+	return "orders.placed"
+}
+
+func (m *orderPlaced) PartitionKey() string {
+	return m.CustomerID
+}
+
+func newRoutingProducer(topicStrategy, keyStrategy string) *Producer {
+	return &Producer{
+		config: TransportConfig{
+			Name: "kafka",
+			Options: OptionsConfig{
+				Topics: []string{"events"},
+				Topic:  TopicConfig{Strategy: topicStrategy, Routes: []string{"audit", "orders.vip", "orders.placed"}},
+				Key:    KeyConfig{Strategy: keyStrategy},
+			},
+		},
+	}
+}
+
+func TestProducer_TargetTopics(t *testing.T) {
+	testCases := []struct {
+		name          string
+		topicStrategy string
+		env           api.Envelope
+		expected      []string
+	}{
+		{
+			name:          "static strategy uses configured topics",
+			topicStrategy: "static",
+			env:           envelope.NewEnvelope(&orderPlaced{}).WithStamp(stamps.RoutingKeyStamp{Key: "ignored"}),
+			expected:      []string{"events"},
+		},
+		{
+			name:          "topic stamp always wins",
+			topicStrategy: "static",
+			env:           envelope.NewEnvelope(&orderPlaced{}).WithStamp(stamps.TopicStamp{Topic: "audit"}),
+			expected:      []string{"audit"},
+		},
+		{
+			name:          "routing key stamp is preferred over routed message",
+			topicStrategy: "routing_key",
+			env:           envelope.NewEnvelope(&orderPlaced{}).WithStamp(stamps.RoutingKeyStamp{Key: "orders.vip"}),
+			expected:      []string{"orders.vip"},
+		},
+		{
+			name:          "routed message provides the topic",
+			topicStrategy: "routing_key",
+			env:           envelope.NewEnvelope(&orderPlaced{}),
+			expected:      []string{"orders.placed"},
+		},
+		{
+			name:          "falls back to configured topics",
+			topicStrategy: "routing_key",
+			env:           envelope.NewEnvelope(&helpers.TestMessage{}),
+			expected:      []string{"events"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			topics, err := newRoutingProducer(tc.topicStrategy, "none").targetTopics(tc.env)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, topics)
+		})
+	}
+
+	t.Run("rejects topics outside of topic.routes", func(t *testing.T) {
+		p := newRoutingProducer("routing_key", "none")
+
+		_, err := p.targetTopics(envelope.NewEnvelope(&orderPlaced{}).WithStamp(stamps.TopicStamp{Topic: "payments"}))
+
+		require.ErrorIs(t, err, ErrUnknownTopic)
+	})
+}
+
+func TestTransportConfig_RoutableTopics(t *testing.T) {
+	cfg := newRoutingProducer("routing_key", "none").config
+	cfg.Options.Topic.Routes = append(cfg.Options.Topic.Routes, "events")
+
+	assert.Equal(t, []string{"events", "audit", "orders.vip", "orders.placed"}, cfg.routableTopics())
+	assert.Contains(t, cfg.setupTopicNames(), "orders.placed.delay.1m")
+}
+
+func TestProducer_ExtractMessageKey(t *testing.T) {
+	env := envelope.NewEnvelope(&orderPlaced{OrderID: "o-1", CustomerID: "c-7"}).
+		WithStamp(stamps.MessageIDStamp{MessageID: "id-1"})
+
+	testCases := []struct {
+		strategy string
+		expected []byte
+	}{
+		{strategy: "none", expected: nil},
+		{strategy: "message_id", expected: []byte("id-1")},
+		{strategy: "routing_key", expected: []byte("orders.placed")},
+		{strategy: "partition_key", expected: []byte("c-7")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.strategy, func(t *testing.T) {
+			key, err := newRoutingProducer("static", tc.strategy).extractMessageKey(env)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, key)
+		})
+	}
+
+	t.Run("partition_key requires the interface", func(t *testing.T) {
+		_, err := newRoutingProducer("static", "partition_key").
+			extractMessageKey(envelope.NewEnvelope(&helpers.TestMessage{}))
+
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,237 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type topicAdmin interface {
+	CreateTopics(context.Context, *kafka.CreateTopicsRequest) (*kafka.CreateTopicsResponse, error)
+	Metadata(context.Context, *kafka.MetadataRequest) (*kafka.MetadataResponse, error)
+	DescribeConfigs(context.Context, *kafka.DescribeConfigsRequest) (*kafka.DescribeConfigsResponse, error)
+}
+
+func setupTopics(ctx context.Context, admin topicAdmin, cfg TransportConfig) error {
+	names := cfg.setupTopicNames()
+	if len(names) == 0 && cfg.Options.Reply.Topic == "" {
+		return nil
+	}
+
+	setup := cfg.Options.Setup
+	configs := cfg.setupTopicConfigs(names)
+
+	request := &kafka.CreateTopicsRequest{Topics: make([]kafka.TopicConfig, 0, len(names)+1)}
+	for _, name := range names {
+		request.Topics = append(request.Topics, kafka.TopicConfig{
+			Topic:             name,
+			NumPartitions:     setup.partitions(),
+			ReplicationFactor: setup.replicationFactor(),
+			ConfigEntries:     configs[name],
+		})
+	}
+
+	replyTopic := cfg.Options.Reply.Topic
+	if replyTopic != "" && !slices.Contains(names, replyTopic) {
+		request.Topics = append(request.Topics, kafka.TopicConfig{
+			Topic:             replyTopic,
+			NumPartitions:     1,
+			ReplicationFactor: setup.replicationFactor(),
+			ConfigEntries:     setup.topicConfigs(false),
+		})
+	}
+
+	res, err := admin.CreateTopics(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to create topics: %w", err)
+	}
+
+	if replyTopic != "" {
+		if createErr := res.Errors[replyTopic]; createErr != nil && !errors.Is(createErr, kafka.TopicAlreadyExists) {
+			return fmt.Errorf("failed to create reply topic '%s': %w", replyTopic, createErr)
+		}
+	}
+
+	existing := make([]string, 0, len(names))
+	for _, name := range names {
+		createErr := res.Errors[name]
+		switch {
+		case createErr == nil:
+		case errors.Is(createErr, kafka.TopicAlreadyExists):
+			existing = append(existing, name)
+		default:
+			return fmt.Errorf("failed to create topic '%s': %w", name, createErr)
+		}
+	}
+
+	if len(existing) == 0 {
+		return nil
+	}
+
+	return verifyTopics(ctx, admin, existing, setup, configs)
+}
+
+// verifyTopics checks existing topics against the settings that were set explicitly:
+// partitions and replication_factor only when configured, configs per topic.
+func verifyTopics(
+	ctx context.Context,
+	admin topicAdmin,
+	names []string,
+	setup SetupConfig,
+	configs map[string][]kafka.ConfigEntry,
+) error {
+	metadata, err := admin.Metadata(ctx, &kafka.MetadataRequest{Topics: names})
+	if err != nil {
+		return fmt.Errorf("failed to fetch topic metadata: %w", err)
+	}
+
+	for _, topic := range metadata.Topics {
+		if topic.Error != nil {
+			return fmt.Errorf("failed to describe topic '%s': %w", topic.Name, topic.Error)
+		}
+
+		if setup.Partitions > 0 && len(topic.Partitions) != setup.Partitions {
+			return fmt.Errorf(
+				"topic '%s' has %d partitions, expected %d",
+				topic.Name,
+				len(topic.Partitions),
+				setup.Partitions,
+			)
+		}
+
+		if setup.ReplicationFactor <= 0 || len(topic.Partitions) == 0 {
+			continue
+		}
+
+		if replicas := len(topic.Partitions[0].Replicas); replicas != setup.ReplicationFactor {
+			return fmt.Errorf(
+				"topic '%s' has replication factor %d, expected %d",
+				topic.Name,
+				replicas,
+				setup.ReplicationFactor,
+			)
+		}
+	}
+
+	request := &kafka.DescribeConfigsRequest{Resources: make([]kafka.DescribeConfigRequestResource, 0, len(names))}
+	for _, name := range names {
+		if len(configs[name]) == 0 {
+			continue
+		}
+
+		configNames := make([]string, 0, len(configs[name]))
+		for _, entry := range configs[name] {
+			configNames = append(configNames, entry.ConfigName)
+		}
+
+		request.Resources = append(request.Resources, kafka.DescribeConfigRequestResource{
+			ResourceType: kafka.ResourceTypeTopic,
+			ResourceName: name,
+			ConfigNames:  configNames,
+		})
+	}
+
+	if len(request.Resources) == 0 {
+		return nil
+	}
+
+	described, err := admin.DescribeConfigs(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to describe topic configs: %w", err)
+	}
+
+	for _, resource := range described.Resources {
+		if resource.Error != nil {
+			return fmt.Errorf("failed to describe configs of topic '%s': %w", resource.ResourceName, resource.Error)
+		}
+
+		actual := make(map[string]string, len(resource.ConfigEntries))
+		for _, entry := range resource.ConfigEntries {
+			actual[entry.ConfigName] = entry.ConfigValue
+		}
+
+		for _, entry := range configs[resource.ResourceName] {
+			if actual[entry.ConfigName] != entry.ConfigValue {
+				return fmt.Errorf(
+					"topic '%s' has %s=%q, expected %q",
+					resource.ResourceName,
+					entry.ConfigName,
+					actual[entry.ConfigName],
+					entry.ConfigValue,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *TransportConfig) setupTopicNames() []string {
+	names := make([]string, 0, len(c.Options.Topics))
+	for _, topic := range c.routableTopics() {
+		names = append(names, topic)
+		names = append(names, c.relayTopics(topic)...)
+		names = append(names, deadLetterTopicName(topic))
+	}
+
+	if c.outboxEnabled() {
+		names = append(names, c.outboxTopic())
+	}
+
+	slices.Sort(names)
+
+	return slices.Compact(names)
+}
+
+// setupTopicConfigs applies retention and cleanup_policy to the configured topics only:
+// delay, retry and dead letter topics get just the raw configs, as a compacted or short
+// lived relay topic would lose the messages it holds.
+func (c *TransportConfig) setupTopicConfigs(names []string) map[string][]kafka.ConfigEntry {
+	routable := c.routableTopics()
+
+	configs := make(map[string][]kafka.ConfigEntry, len(names))
+	for _, name := range names {
+		configs[name] = c.Options.Setup.topicConfigs(slices.Contains(routable, name))
+	}
+
+	return configs
+}
+
+func (s SetupConfig) topicConfigs(retention bool) []kafka.ConfigEntry {
+	values := make(map[string]string, len(s.Configs))
+	for name, value := range s.Configs {
+		values[name] = value
+	}
+
+	if retention && s.Retention > 0 {
+		values["retention.ms"] = strconv.FormatInt(s.Retention.Milliseconds(), 10)
+	}
+
+	if retention && s.CleanupPolicy != "" {
+		values["cleanup.policy"] = s.CleanupPolicy
+	}
+
+	entries := make([]kafka.ConfigEntry, 0, len(values))
+	for name, value := range values {
+		entries = append(entries, kafka.ConfigEntry{ConfigName: name, ConfigValue: value})
+	}
+
+	slices.SortFunc(entries, func(a, b kafka.ConfigEntry) int {
+		return strings.Compare(a.ConfigName, b.ConfigName)
+	})
+
+	return entries
+}
+
+func (s SetupConfig) partitions() int {
+	return max(s.Partitions, 1)
+}
+
+func (s SetupConfig) replicationFactor() int {
+	return max(s.ReplicationFactor, 1)
+}
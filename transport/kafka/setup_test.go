@@ -0,0 +1,241 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTopicAdmin struct {
+	created   *kafka.CreateTopicsRequest
+	existing  map[string]kafka.Topic
+	configs   map[string]map[string]string
+	described bool
+}
+
+func (a *fakeTopicAdmin) CreateTopics(
+	_ context.Context,
+	req *kafka.CreateTopicsRequest,
+) (*kafka.CreateTopicsResponse, error) {
+	a.created = req
+
+	res := &kafka.CreateTopicsResponse{Errors: make(map[string]error)}
+	for _, topic := range req.Topics {
+		if _, ok := a.existing[topic.Topic]; ok {
+			res.Errors[topic.Topic] = kafka.TopicAlreadyExists
+		} else {
+			res.Errors[topic.Topic] = nil
+		}
+	}
+
+	return res, nil
+}
+
+func (a *fakeTopicAdmin) Metadata(_ context.Context, req *kafka.MetadataRequest) (*kafka.MetadataResponse, error) {
+	res := &kafka.MetadataResponse{}
+	for _, name := range req.Topics {
+		res.Topics = append(res.Topics, a.existing[name])
+	}
+
+	return res, nil
+}
+
+func (a *fakeTopicAdmin) DescribeConfigs(
+	_ context.Context,
+	req *kafka.DescribeConfigsRequest,
+) (*kafka.DescribeConfigsResponse, error) {
+	a.described = true
+
+	res := &kafka.DescribeConfigsResponse{}
+	for _, resource := range req.Resources {
+		described := kafka.DescribeConfigResponseResource{ResourceName: resource.ResourceName}
+		for name, value := range a.configs[resource.ResourceName] {
+			described.ConfigEntries = append(described.ConfigEntries, kafka.DescribeConfigResponseConfigEntry{
+				ConfigName:  name,
+				ConfigValue: value,
+			})
+		}
+		res.Resources = append(res.Resources, described)
+	}
+
+	return res, nil
+}
+
+func existingTopic(name string, partitions, replicas int) kafka.Topic {
+	topic := kafka.Topic{Name: name}
+	for id := range partitions {
+		topic.Partitions = append(topic.Partitions, kafka.Partition{
+			Topic:    name,
+			ID:       id,
+			Replicas: make([]kafka.Broker, replicas),
+		})
+	}
+
+	return topic
+}
+
+func newSetupConfig() TransportConfig {
+	return TransportConfig{
+		Name: "kafka",
+		Options: OptionsConfig{
+			Topics:    []string{"orders"},
+			AutoSetup: true,
+			Setup: SetupConfig{
+				Partitions:        6,
+				ReplicationFactor: 3,
+				Retention:         24 * time.Hour,
+				CleanupPolicy:     "delete",
+			},
+		},
+	}
+}
+
+func TestSetupTopics_CreatesTopics(t *testing.T) {
+	cfg := newSetupConfig()
+	cfg.Options.RetryTopics = RetryTopicsConfig{Enabled: true, Levels: 1}
+	cfg.Options.DelayTopics = DelayTopicsConfig{Tiers: []time.Duration{time.Minute}}
+	admin := &fakeTopicAdmin{}
+
+	require.NoError(t, setupTopics(context.Background(), admin, cfg))
+
+	require.NotNil(t, admin.created)
+	names := make([]string, 0, len(admin.created.Topics))
+	for _, topic := range admin.created.Topics {
+		names = append(names, topic.Topic)
+		assert.Equal(t, 6, topic.NumPartitions)
+		assert.Equal(t, 3, topic.ReplicationFactor)
+
+		if topic.Topic != "orders" {
+			assert.Empty(t, topic.ConfigEntries, topic.Topic)
+
+			continue
+		}
+
+		assert.Equal(t, []kafka.ConfigEntry{
+			{ConfigName: "cleanup.policy", ConfigValue: "delete"},
+			{ConfigName: "retention.ms", ConfigValue: "86400000"},
+		}, topic.ConfigEntries)
+	}
+
+	assert.Equal(t, []string{"orders", "orders.delay.1m", "orders.dlq", "orders.retry.1"}, names)
+	assert.False(t, admin.described)
+}
+
+func TestSetupTopics_ReplyTopic(t *testing.T) {
+	cfg := newSetupConfig()
+	cfg.Options.Reply.Topic = "orders.replies"
+	admin := &fakeTopicAdmin{
+		existing: map[string]kafka.Topic{"orders.replies": existingTopic("orders.replies", 1, 3)},
+	}
+
+	require.NoError(t, setupTopics(context.Background(), admin, cfg))
+
+	reply := admin.created.Topics[len(admin.created.Topics)-1]
+	assert.Equal(t, "orders.replies", reply.Topic)
+	assert.Equal(t, 1, reply.NumPartitions)
+	assert.False(t, admin.described)
+}
+
+func TestSetupTopics_VerifiesExistingTopics(t *testing.T) {
+	matchingConfigs := map[string]string{"cleanup.policy": "delete", "retention.ms": "86400000"}
+
+	testCases := []struct {
+		name    string
+		topic   kafka.Topic
+		configs map[string]string
+		errText string
+	}{
+		{
+			name:    "matching topic",
+			topic:   existingTopic("orders", 6, 3),
+			configs: matchingConfigs,
+		},
+		{
+			name:    "partition count mismatch",
+			topic:   existingTopic("orders", 3, 3),
+			configs: matchingConfigs,
+			errText: "topic 'orders' has 3 partitions, expected 6",
+		},
+		{
+			name:    "replication factor mismatch",
+			topic:   existingTopic("orders", 6, 1),
+			configs: matchingConfigs,
+			errText: "topic 'orders' has replication factor 1, expected 3",
+		},
+		{
+			name:    "config mismatch",
+			topic:   existingTopic("orders", 6, 3),
+			configs: map[string]string{"cleanup.policy": "compact", "retention.ms": "86400000"},
+			errText: `topic 'orders' has cleanup.policy="compact", expected "delete"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newSetupConfig()
+			admin := &fakeTopicAdmin{
+				existing: map[string]kafka.Topic{"orders": tc.topic},
+				configs:  map[string]map[string]string{"orders": tc.configs},
+			}
+
+			err := setupTopics(context.Background(), admin, cfg)
+
+			if tc.errText == "" {
+				require.NoError(t, err)
+				assert.True(t, admin.described)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errText)
+		})
+	}
+}
+
+func TestSetupTopics_VerifiesOnlyExplicitSettings(t *testing.T) {
+	cfg := newSetupConfig()
+	cfg.Options.Setup = SetupConfig{Configs: map[string]string{"min.insync.replicas": "2"}}
+	cfg.Options.DelayTopics = DelayTopicsConfig{Tiers: []time.Duration{time.Minute}}
+	admin := &fakeTopicAdmin{
+		existing: map[string]kafka.Topic{
+			"orders":          existingTopic("orders", 12, 3),
+			"orders.delay.1m": existingTopic("orders.delay.1m", 12, 3),
+		},
+		configs: map[string]map[string]string{
+			"orders":          {"min.insync.replicas": "2"},
+			"orders.delay.1m": {"min.insync.replicas": "2"},
+		},
+	}
+
+	require.NoError(t, setupTopics(context.Background(), admin, cfg))
+
+	for _, topic := range admin.created.Topics {
+		assert.Equal(t, 1, topic.NumPartitions)
+		assert.Equal(t, 1, topic.ReplicationFactor)
+		assert.Equal(t, []kafka.ConfigEntry{{ConfigName: "min.insync.replicas", ConfigValue: "2"}}, topic.ConfigEntries)
+	}
+	assert.True(t, admin.described)
+}
+
+func TestSetupTopics_RelayTopicsKeepTheirRetention(t *testing.T) {
+	cfg := newSetupConfig()
+	cfg.Options.DelayTopics = DelayTopicsConfig{Tiers: []time.Duration{time.Minute}}
+	admin := &fakeTopicAdmin{
+		existing: map[string]kafka.Topic{"orders.delay.1m": existingTopic("orders.delay.1m", 6, 3)},
+		configs:  map[string]map[string]string{"orders.delay.1m": {"cleanup.policy": "compact"}},
+	}
+
+	require.NoError(t, setupTopics(context.Background(), admin, cfg))
+	assert.False(t, admin.described)
+}
+
+func TestTransport_SetupDisabled(t *testing.T) {
+	transport := &Transport{cfg: TransportConfig{Options: OptionsConfig{Topics: []string{"orders"}}}}
+
+	require.NoError(t, transport.Setup(context.Background()))
+}
@@ -14,16 +14,21 @@ import (
 type ConnectionKafka interface {
 	CreateReader(kafka.ReaderConfig) *kafka.Reader
 	CreateWriter(string, ProducerOptionsConfig, bool, kafka.Balancer) *kafka.Writer
+	Admin() *kafka.Client
 }
 
 type Transport struct {
 	cfg        TransportConfig
-	producer   api.Producer
+	producer   api.RequestReplyProducer
 	consumer   api.Consumer
 	connection ConnectionKafka
 }
 
 func NewTransport(cfg TransportConfig, serializer api.Serializer) (api.Transport, error) {
+	if err := validateOrdering(cfg.Options.Consumer.Ordering); err != nil {
+		return nil, err
+	}
+
 	u, err := url.Parse(cfg.DSN)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse dsn: %w", err)
@@ -31,7 +36,19 @@ func NewTransport(cfg TransportConfig, serializer api.Serializer) (api.Transport
 
 	brokers := strings.Split(u.Host, ",")
 
-	connection, errConnection := NewConnection(brokers)
+	applyDSNSecurity(u, &cfg.Options)
+
+	tlsConfig, errTLS := newTLSConfig(cfg.Options.TLS)
+	if errTLS != nil {
+		return nil, fmt.Errorf("failed to configure tls: %w", errTLS)
+	}
+
+	mechanism, errSASL := newSASLMechanism(cfg.Options.SASL)
+	if errSASL != nil {
+		return nil, fmt.Errorf("failed to configure sasl: %w", errSASL)
+	}
+
+	connection, errConnection := NewConnection(brokers, tlsConfig, mechanism)
 	if errConnection != nil {
 		return nil, fmt.Errorf("failed to create connection: %w", errConnection)
 	}
@@ -62,6 +79,22 @@ func (t *Transport) Send(ctx context.Context, env api.Envelope) error {
 	return t.producer.Send(ctx, env)
 }
 
+func (t *Transport) Setup(ctx context.Context) error {
+	if !t.cfg.Options.AutoSetup {
+		return nil
+	}
+
+	return setupTopics(ctx, t.connection.Admin(), t.cfg)
+}
+
+func (t *Transport) Request(ctx context.Context, env api.Envelope) (api.Envelope, error) {
+	return t.producer.Request(ctx, env)
+}
+
+func (t *Transport) Reply(ctx context.Context, request api.Envelope, reply api.Envelope) error {
+	return t.producer.Reply(ctx, request, reply)
+}
+
 func (t *Transport) Receive(ctx context.Context, handler func(context.Context, api.Envelope) error) error {
 	return t.consumer.Consume(ctx, handler)
 }
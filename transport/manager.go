@@ -2,11 +2,15 @@ package transport
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"sync"
 
 	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/ack"
+	"github.com/gerfey/messenger/core/envelope"
 	"github.com/gerfey/messenger/core/event"
+	"github.com/gerfey/messenger/core/stamps"
 )
 
 type Manager struct {
@@ -107,39 +111,23 @@ func (m *Manager) receiveTransport(ctx context.Context, t api.Transport) {
 				return errMessageReceived
 			}
 
-			err := m.handler(ctx, env)
-
-			if err != nil {
-				errMessageFailed := m.eventDispatcher.Dispatch(ctx, event.WorkerMessageFailedEvent{
-					Ctx:           ctx,
-					Envelope:      env,
-					TransportName: t.Name(),
-					Error:         err,
-				})
-				if errMessageFailed != nil {
-					return errMessageFailed
-				}
-
-				errSendFailed := m.eventDispatcher.Dispatch(ctx, event.SendFailedMessageEvent{
-					Envelope:      env,
-					Error:         err,
-					TransportName: t.Name(),
-				})
-				if errSendFailed != nil {
-					return errSendFailed
-				}
-			} else {
-				errMessageHandled := m.eventDispatcher.Dispatch(ctx, event.WorkerMessageHandledEvent{
-					Ctx:           ctx,
-					Envelope:      env,
-					TransportName: t.Name(),
-				})
-				if errMessageHandled != nil {
-					return errMessageHandled
-				}
+			transportAck, ok := envelope.LastStampOf[stamps.AckStamp](env)
+			if !ok || transportAck.Acknowledger == nil {
+				return m.settle(ctx, t, env, m.handler(ctx, env))
 			}
 
-			return err
+			acknowledger := ack.New(func(err error) {
+				transportAck.Acknowledger.Ack(m.settle(context.WithoutCancel(ctx), t, env, err))
+			})
+
+			err := m.handler(ctx, env.WithStamp(stamps.AckStamp{Acknowledger: acknowledger}))
+			if acknowledger.Deferred() {
+				transportAck.Acknowledger.Defer()
+
+				return nil
+			}
+
+			return m.settle(ctx, t, env, err)
 		})
 
 		if err != nil {
@@ -148,6 +136,39 @@ func (m *Manager) receiveTransport(ctx context.Context, t api.Transport) {
 	}(t)
 }
 
+func (m *Manager) settle(ctx context.Context, t api.Transport, env api.Envelope, err error) error {
+	if err != nil {
+		errMessageFailed := m.eventDispatcher.Dispatch(ctx, event.WorkerMessageFailedEvent{
+			Ctx:           ctx,
+			Envelope:      env,
+			TransportName: t.Name(),
+			Error:         err,
+		})
+		if errMessageFailed != nil {
+			return errMessageFailed
+		}
+
+		sendFailedEvent := event.NewSendFailedMessageEvent(env, err, t.Name())
+
+		errSendFailed := m.eventDispatcher.Dispatch(ctx, sendFailedEvent)
+		if errSendFailed != nil {
+			return errSendFailed
+		}
+
+		if sendFailedEvent.HandedOff() {
+			return fmt.Errorf("%w: %w", api.ErrMessageHandedOff, err)
+		}
+
+		return err
+	}
+
+	return m.eventDispatcher.Dispatch(ctx, event.WorkerMessageHandledEvent{
+		Ctx:           ctx,
+		Envelope:      env,
+		TransportName: t.Name(),
+	})
+}
+
 func (m *Manager) stringInSlice(s string, list []string) bool {
 	for _, item := range list {
 		if item == s {
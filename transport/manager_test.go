@@ -13,6 +13,10 @@ import (
 	"github.com/gerfey/messenger/transport"
 
 	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/ack"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/event"
+	"github.com/gerfey/messenger/core/stamps"
 	"github.com/gerfey/messenger/tests/helpers"
 )
 
@@ -113,3 +117,115 @@ func TestManager_Integration(t *testing.T) {
 		manager.Stop()
 	})
 }
+
+type singleMessageTransport struct {
+	helpers.TestTransport
+	env    api.Envelope
+	result chan error
+}
+
+func (t *singleMessageTransport) Receive(ctx context.Context, handler func(context.Context, api.Envelope) error) error {
+	t.result <- handler(ctx, t.env)
+
+	<-ctx.Done()
+
+	return nil
+}
+
+func TestManager_HandedOff(t *testing.T) {
+	run := func(t *testing.T, listener func(event.SendFailedMessageEvent)) error {
+		t.Helper()
+
+		handlerErr := errors.New("handler error")
+		handler := func(_ context.Context, _ api.Envelope) error {
+			return handlerErr
+		}
+
+		logger, _ := helpers.NewFakeLogger()
+		dispatcher := event.NewEventDispatcher(logger)
+		dispatcher.AddListener(event.SendFailedMessageEvent{}, listener)
+
+		manager := transport.NewManager(logger, handler, dispatcher)
+
+		tr := &singleMessageTransport{
+			TestTransport: helpers.TestTransport{TransportName: "async"},
+			env:           envelope.NewEnvelope(&helpers.TestMessage{ID: "1"}),
+			result:        make(chan error, 1),
+		}
+		manager.AddTransport(tr)
+
+		ctx, cancel := context.WithCancel(t.Context())
+
+		manager.Start(ctx, []string{"async"})
+
+		err := <-tr.result
+		require.ErrorIs(t, err, handlerErr)
+
+		cancel()
+		manager.Stop()
+
+		return err
+	}
+
+	t.Run("wraps error when failure was handed off", func(t *testing.T) {
+		err := run(t, func(evt event.SendFailedMessageEvent) {
+			evt.MarkHandedOff()
+		})
+
+		assert.ErrorIs(t, err, api.ErrMessageHandedOff)
+	})
+
+	t.Run("returns plain error when nobody took the message", func(t *testing.T) {
+		err := run(t, func(_ event.SendFailedMessageEvent) {})
+
+		assert.NotErrorIs(t, err, api.ErrMessageHandedOff)
+	})
+}
+
+func TestManager_DeferredAck(t *testing.T) {
+	handlerErr := errors.New("handler error")
+	handler := func(_ context.Context, env api.Envelope) error {
+		ackStamp, ok := envelope.LastStampOf[stamps.AckStamp](env)
+		require.True(t, ok)
+
+		ackStamp.Acknowledger.Defer()
+		go ackStamp.Acknowledger.Ack(handlerErr)
+
+		return nil
+	}
+
+	logger, _ := helpers.NewFakeLogger()
+	dispatcher := event.NewEventDispatcher(logger)
+	dispatcher.AddListener(event.SendFailedMessageEvent{}, func(evt event.SendFailedMessageEvent) {
+		evt.MarkHandedOff()
+	})
+
+	manager := transport.NewManager(logger, handler, dispatcher)
+
+	acked := make(chan error, 1)
+	transportAck := ack.New(func(err error) {
+		acked <- err
+	})
+
+	tr := &singleMessageTransport{
+		TestTransport: helpers.TestTransport{TransportName: "async"},
+		env: envelope.NewEnvelope(&helpers.TestMessage{ID: "1"}).
+			WithStamp(stamps.AckStamp{Acknowledger: transportAck}),
+		result: make(chan error, 1),
+	}
+	manager.AddTransport(tr)
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	manager.Start(ctx, []string{"async"})
+
+	require.NoError(t, <-tr.result)
+	assert.True(t, transportAck.Deferred())
+
+	err := <-acked
+	require.ErrorIs(t, err, handlerErr)
+	assert.ErrorIs(t, err, api.ErrMessageHandedOff)
+
+	cancel()
+	manager.Stop()
+}
@@ -1,5 +1,7 @@
 package redis
 
+import "time"
+
 type TransportConfig struct {
 	Name    string
 	DSN     string
@@ -7,8 +9,14 @@ type TransportConfig struct {
 }
 
 type OptionsConfig struct {
-	AutoSetup bool   `yaml:"auto_setup" default:"true"`
-	Stream    string `yaml:"stream"     default:"messages"`
-	Group     string `yaml:"group"      default:"default"`
-	Consumer  string `yaml:"consumer"   default:"consumer"`
+	AutoSetup bool        `yaml:"auto_setup" default:"true"`
+	Stream    string      `yaml:"stream"     default:"messages"`
+	Group     string      `yaml:"group"      default:"default"`
+	Consumer  string      `yaml:"consumer"   default:"consumer"`
+	Reply     ReplyConfig `yaml:"reply"`
+}
+
+type ReplyConfig struct {
+	Stream string        `yaml:"stream"` // empty: "<stream>.replies.<uuid>" per transport instance
+	TTL    time.Duration `yaml:"ttl"    default:"1m"`
 }
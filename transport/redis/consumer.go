@@ -3,6 +3,7 @@ package redis
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"sync"
 	"time"
@@ -10,11 +11,13 @@ import (
 	"github.com/redis/go-redis/v9"
 
 	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/ack"
 	"github.com/gerfey/messenger/core/stamps"
 )
 
 const (
-	defaultBatchSize = 10
+	defaultBatchSize  = 10
+	errorBackoffDelay = 100 * time.Millisecond
 )
 
 type Consumer struct {
@@ -66,6 +69,10 @@ func (c *Consumer) consumeLoop(ctx context.Context, handler func(context.Context
 		case <-ctx.Done():
 			return
 		default:
+			if _, err := promoteDelayed(ctx, rdb, stream); err != nil && !backoff(ctx) {
+				return
+			}
+
 			streams, err := rdb.XReadGroup(ctx, &redis.XReadGroupArgs{
 				Group:    group,
 				Consumer: consumer,
@@ -75,6 +82,10 @@ func (c *Consumer) consumeLoop(ctx context.Context, handler func(context.Context
 			}).Result()
 
 			if err != nil && !errors.Is(err, redis.Nil) {
+				if !backoff(ctx) {
+					return
+				}
+
 				continue
 			}
 
@@ -87,19 +98,57 @@ func (c *Consumer) consumeLoop(ctx context.Context, handler func(context.Context
 	}
 }
 
+func backoff(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(errorBackoffDelay):
+		return true
+	}
+}
+
 func (c *Consumer) handleMessage(
 	ctx context.Context,
 	msg redis.XMessage,
 	handler func(context.Context, api.Envelope) error,
 ) {
+	env, errUnmarshal := decodeMessage(c.serializer, msg)
+	if errUnmarshal != nil {
+		return
+	}
+
+	acknowledger := ack.New(func(err error) {
+		if err != nil && !errors.Is(err, api.ErrMessageHandedOff) {
+			return
+		}
+
+		_ = c.connection.Client().XAck(
+			context.WithoutCancel(ctx),
+			c.config.Options.Stream,
+			c.config.Options.Group,
+			msg.ID,
+		).Err()
+	})
+
+	env = env.
+		WithStamp(stamps.ReceivedStamp{Transport: c.config.Name}).
+		WithStamp(stamps.AckStamp{Acknowledger: acknowledger})
+
+	errHandler := handler(ctx, env)
+	if !acknowledger.Deferred() {
+		acknowledger.Ack(errHandler)
+	}
+}
+
+func decodeMessage(serializer api.Serializer, msg redis.XMessage) (api.Envelope, error) {
 	bodyRaw, ok := msg.Values["body"]
 	if !ok {
-		return
+		return nil, fmt.Errorf("redis: message '%s' has no body", msg.ID)
 	}
 
 	bodyBytes, ok := bodyRaw.(string)
 	if !ok {
-		return
+		return nil, fmt.Errorf("redis: message '%s' has invalid body", msg.ID)
 	}
 
 	headers := make(map[string]string)
@@ -111,18 +160,10 @@ func (c *Consumer) handleMessage(
 		}
 	}
 
-	env, errUnmarshal := c.serializer.Unmarshal([]byte(bodyBytes), headers)
-	if errUnmarshal != nil {
-		return
+	env, err := serializer.Unmarshal([]byte(bodyBytes), headers)
+	if err != nil {
+		return nil, fmt.Errorf("redis: unmarshal message '%s' failed: %w", msg.ID, err)
 	}
 
-	env = env.WithStamp(stamps.ReceivedStamp{Transport: c.config.Name})
-
-	if errHandler := handler(ctx, env); errHandler != nil {
-		return
-	}
-
-	if err := c.connection.Client().XAck(ctx, c.config.Options.Stream, c.config.Options.Group, msg.ID).Err(); err != nil {
-		return
-	}
+	return env, nil
 }
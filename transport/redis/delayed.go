@@ -0,0 +1,131 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	delayedSetSuffix  = ":delayed"
+	promoteBatchSize  = 100
+	promoteDelayedLua = `
+local items = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, item in ipairs(items) do
+	local entry = cjson.decode(item)
+	local fields = {}
+	for k, v in pairs(entry.values) do
+		table.insert(fields, k)
+		table.insert(fields, v)
+	end
+	local added = redis.pcall('XADD', KEYS[2], entry.stream_id or '*', unpack(fields))
+	if type(added) == 'table' and added.err then
+		redis.call('XADD', KEYS[2], '*', unpack(fields))
+	end
+	redis.call('ZREM', KEYS[1], item)
+end
+return #items
+`
+)
+
+// promoteDelayedScript moves due entries into the stream. An entry keeps the stream ID of
+// its MessageIDStamp unless the stream has moved past it by then.
+var promoteDelayedScript = redis.NewScript(promoteDelayedLua)
+
+type delayedEntry struct {
+	ID       string            `json:"id"`
+	StreamID string            `json:"stream_id,omitempty"`
+	Values   map[string]string `json:"values"`
+}
+
+func delayedSetName(stream string) string {
+	return stream + delayedSetSuffix
+}
+
+func addDelayed(
+	ctx context.Context,
+	client *redis.Client,
+	stream string,
+	streamID string,
+	values map[string]string,
+	availableAt time.Time,
+) error {
+	member, err := json.Marshal(delayedEntry{ID: uuid.New().String(), StreamID: streamID, Values: values})
+	if err != nil {
+		return fmt.Errorf("redis: marshal delayed entry failed: %w", err)
+	}
+
+	err = client.ZAdd(ctx, delayedSetName(stream), redis.Z{
+		Score:  float64(availableAt.UnixMilli()),
+		Member: string(member),
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("redis: ZADD failed: %w", err)
+	}
+
+	return nil
+}
+
+func promoteDelayed(ctx context.Context, client *redis.Client, stream string) (int64, error) {
+	return promoteDelayedScript.Run(
+		ctx,
+		client,
+		[]string{delayedSetName(stream), stream},
+		time.Now().UnixMilli(),
+		promoteBatchSize,
+	).Int64()
+}
+
+func (e delayedEntry) message() redis.XMessage {
+	values := make(map[string]any, len(e.Values))
+	for k, v := range e.Values {
+		values[k] = v
+	}
+
+	return redis.XMessage{ID: e.ID, Values: values}
+}
+
+// listDelayed returns the entries still waiting in the delayed set, the earliest first,
+// keyed by their set member so they can be removed before they reach the stream.
+func listDelayed(ctx context.Context, client *redis.Client, stream string, limit int) ([]string, []delayedEntry, error) {
+	stop := int64(-1)
+	if limit > 0 {
+		stop = int64(limit) - 1
+	}
+
+	members, err := client.ZRange(ctx, delayedSetName(stream), 0, stop).Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("redis: ZRANGE failed: %w", err)
+	}
+
+	entries := make([]delayedEntry, 0, len(members))
+	for _, member := range members {
+		var entry delayedEntry
+		if errDecode := json.Unmarshal([]byte(member), &entry); errDecode != nil {
+			return nil, nil, fmt.Errorf("redis: unmarshal delayed entry failed: %w", errDecode)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return members, entries, nil
+}
+
+func findDelayed(ctx context.Context, client *redis.Client, stream string, id string) (string, delayedEntry, bool, error) {
+	members, entries, err := listDelayed(ctx, client, stream, 0)
+	if err != nil {
+		return "", delayedEntry{}, false, err
+	}
+
+	for i, entry := range entries {
+		if entry.ID == id {
+			return members[i], entry, true, nil
+		}
+	}
+
+	return "", delayedEntry{}, false, nil
+}
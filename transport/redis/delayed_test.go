@@ -0,0 +1,37 @@
+package redis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/tests/helpers"
+)
+
+func TestDelayedEntry_Decode(t *testing.T) {
+	serializer := helpers.NewTestSerializer(&helpers.TestMessage{})
+
+	payload, headers, err := serializer.Marshal(envelope.NewEnvelope(&helpers.TestMessage{Content: "later"}))
+	require.NoError(t, err)
+
+	values := map[string]string{"body": string(payload)}
+	for k, v := range headers {
+		values["header_"+k] = v
+	}
+
+	member, err := json.Marshal(delayedEntry{ID: "entry-1", Values: values})
+	require.NoError(t, err)
+
+	var entry delayedEntry
+	require.NoError(t, json.Unmarshal(member, &entry))
+
+	msg := entry.message()
+	assert.Equal(t, "entry-1", msg.ID)
+
+	env, err := decodeMessage(serializer, msg)
+	require.NoError(t, err)
+	assert.Equal(t, "later", env.Message().(*helpers.TestMessage).Content)
+}
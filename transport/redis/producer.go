@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 
@@ -17,13 +18,19 @@ type Producer struct {
 	config     TransportConfig
 	serializer api.Serializer
 	connection ConnectionRedis
+	replies    *replies
 }
 
-func NewProducer(config TransportConfig, serializer api.Serializer, connection ConnectionRedis) (api.Producer, error) {
+func NewProducer(
+	config TransportConfig,
+	serializer api.Serializer,
+	connection ConnectionRedis,
+) (api.RequestReplyProducer, error) {
 	return &Producer{
 		config:     config,
 		serializer: serializer,
 		connection: connection,
+		replies:    newReplies(config),
 	}, nil
 }
 
@@ -33,14 +40,6 @@ func (p *Producer) Send(ctx context.Context, env api.Envelope) error {
 		return fmt.Errorf("redis: marshal envelope failed: %w", err)
 	}
 
-	data := map[string]any{
-		"body": payload,
-	}
-
-	for k, v := range headers {
-		data["header_"+k] = v
-	}
-
 	stream := p.config.Options.Stream
 	if stream == "" {
 		return errors.New("redis: stream name is not configured")
@@ -53,10 +52,27 @@ func (p *Producer) Send(ctx context.Context, env api.Envelope) error {
 		}
 	}
 
+	if delayStamp, ok := envelope.LastStampOf[stamps.DelayStamp](env); ok && delayStamp.Milliseconds > 0 {
+		values := map[string]string{
+			"body": string(payload),
+		}
+
+		for k, v := range headers {
+			values["header_"+k] = v
+		}
+
+		streamID := ""
+		if id != "*" {
+			streamID = id
+		}
+
+		return addDelayed(ctx, p.connection.Client(), stream, streamID, values, time.Now().Add(delayStamp.Duration()))
+	}
+
 	_, err = p.connection.Client().XAdd(ctx, &redis.XAddArgs{
 		ID:     id,
 		Stream: stream,
-		Values: data,
+		Values: streamValues(payload, headers),
 	}).Result()
 	if err != nil {
 		return fmt.Errorf("redis: XADD failed: %w", err)
@@ -66,9 +82,23 @@ func (p *Producer) Send(ctx context.Context, env api.Envelope) error {
 }
 
 func (p *Producer) Close() error {
+	p.replies.cancel()
+
 	return nil
 }
 
+func streamValues(payload []byte, headers map[string]string) map[string]any {
+	values := map[string]any{
+		"body": payload,
+	}
+
+	for k, v := range headers {
+		values["header_"+k] = v
+	}
+
+	return values
+}
+
 func (p *Producer) isValidRedisStreamID(id string) bool {
 	return regexp.MustCompile(`^\d+-\d+$`).MatchString(id)
 }
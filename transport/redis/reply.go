@@ -0,0 +1,150 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/rpc"
+	"github.com/gerfey/messenger/core/stamps"
+)
+
+const (
+	replyStreamInfix   = ".replies."
+	replyStreamMaxLen  = 1000
+	replyReadBatchSize = 100
+	replyReadBlock     = time.Second
+	replyCorrelationID = "correlation_id"
+)
+
+var ErrNoReplyAddress = errors.New("redis: request has no reply stream")
+
+type replies struct {
+	stream  string
+	pending *rpc.Pending
+	once    sync.Once
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+func newReplies(config TransportConfig) *replies {
+	stream := config.Options.Reply.Stream
+	if stream == "" {
+		stream = config.Options.Stream + replyStreamInfix + uuid.New().String()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &replies{
+		stream:  stream,
+		pending: rpc.NewPending(),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+func (p *Producer) Request(ctx context.Context, env api.Envelope) (api.Envelope, error) {
+	p.replies.once.Do(func() {
+		go p.receiveReplies(p.replies.ctx)
+	})
+
+	requestStamp, _ := envelope.LastStampOf[stamps.RequestStamp](env)
+	env = env.WithStamp(stamps.RequestStamp{CorrelationID: requestStamp.CorrelationID, ReplyTo: p.replies.stream})
+
+	return p.replies.pending.Await(ctx, requestStamp.CorrelationID, func() error {
+		return p.Send(ctx, env)
+	})
+}
+
+func (p *Producer) Reply(ctx context.Context, request api.Envelope, reply api.Envelope) error {
+	requestStamp, _ := envelope.LastStampOf[stamps.RequestStamp](request)
+	if requestStamp.ReplyTo == "" {
+		return ErrNoReplyAddress
+	}
+
+	values, err := p.replyValues(reply, requestStamp.CorrelationID)
+	if err != nil {
+		return err
+	}
+
+	client := p.connection.Client()
+
+	_, err = client.XAdd(ctx, &redis.XAddArgs{
+		Stream: requestStamp.ReplyTo,
+		MaxLen: replyStreamMaxLen,
+		Approx: true,
+		Values: values,
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("redis: XADD reply failed: %w", err)
+	}
+
+	if ttl := p.config.Options.Reply.TTL; ttl > 0 {
+		if err = client.Expire(ctx, requestStamp.ReplyTo, ttl).Err(); err != nil {
+			return fmt.Errorf("redis: EXPIRE reply stream failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Producer) replyValues(reply api.Envelope, correlationID string) (map[string]any, error) {
+	payload, headers, err := p.serializer.Marshal(reply)
+	if err != nil {
+		return nil, fmt.Errorf("redis: marshal reply failed: %w", err)
+	}
+
+	values := streamValues(payload, headers)
+	values[replyCorrelationID] = correlationID
+
+	return values, nil
+}
+
+func (p *Producer) receiveReplies(ctx context.Context) {
+	client := p.connection.Client()
+	lastID := "0"
+
+	for ctx.Err() == nil {
+		streams, err := client.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{p.replies.stream, lastID},
+			Count:   replyReadBatchSize,
+			Block:   replyReadBlock,
+		}).Result()
+		if err != nil {
+			if !errors.Is(err, redis.Nil) {
+				select {
+				case <-ctx.Done():
+				case <-time.After(replyReadBlock):
+				}
+			}
+
+			continue
+		}
+
+		for _, s := range streams {
+			for _, msg := range s.Messages {
+				lastID = msg.ID
+				p.resolveReply(msg)
+			}
+		}
+	}
+}
+
+func (p *Producer) resolveReply(msg redis.XMessage) {
+	reply, err := decodeMessage(p.serializer, msg)
+	if err != nil {
+		correlationID, _ := msg.Values[replyCorrelationID].(string)
+		p.replies.pending.Reject(correlationID, err)
+
+		return
+	}
+
+	p.replies.pending.Resolve(reply)
+}
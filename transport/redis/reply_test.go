@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerfey/messenger/core/envelope"
+	"github.com/gerfey/messenger/core/rpc"
+	"github.com/gerfey/messenger/core/stamps"
+	"github.com/gerfey/messenger/tests/helpers"
+)
+
+func TestProducer_ReplyRoundTrip(t *testing.T) {
+	worker := &Producer{serializer: helpers.NewTestSerializer(&helpers.TestMessage{})}
+
+	request := envelope.NewEnvelope(&helpers.TestMessage{Content: "ping"}).
+		WithStamp(stamps.RequestStamp{CorrelationID: "42"})
+	handled := request.WithStamp(stamps.HandledStamp{Handler: "handler", Result: &helpers.TestMessage{Content: "pong"}})
+
+	values, err := worker.replyValues(rpc.NewReply(request, handled, nil), "42")
+	require.NoError(t, err)
+
+	msg := redis.XMessage{ID: "1-0", Values: make(map[string]any, len(values))}
+	for k, v := range values {
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		msg.Values[k] = fmt.Sprint(v)
+	}
+
+	t.Run("decodes registered reply type", func(t *testing.T) {
+		requester := &Producer{
+			serializer: helpers.NewTestSerializer(&helpers.TestMessage{}),
+			replies:    &replies{pending: rpc.NewPending()},
+		}
+
+		reply, err := requester.replies.pending.Await(t.Context(), "42", func() error {
+			requester.resolveReply(msg)
+
+			return nil
+		})
+		require.NoError(t, err)
+
+		result, err := rpc.Result(reply)
+		require.NoError(t, err)
+		assert.Equal(t, &helpers.TestMessage{Content: "pong"}, result.Result)
+	})
+
+	t.Run("fails the request when the reply type is unknown", func(t *testing.T) {
+		requester := &Producer{serializer: helpers.NewTestSerializer(), replies: &replies{pending: rpc.NewPending()}}
+
+		_, err := requester.replies.pending.Await(t.Context(), "42", func() error {
+			requester.resolveReply(msg)
+
+			return nil
+		})
+
+		require.ErrorContains(t, err, "unknown message type: *helpers.TestMessage")
+	})
+}
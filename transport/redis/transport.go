@@ -8,6 +8,7 @@ import (
 	"github.com/redis/go-redis/v9"
 
 	"github.com/gerfey/messenger/api"
+	"github.com/gerfey/messenger/core/stamps"
 )
 
 type ConnectionRedis interface {
@@ -16,7 +17,8 @@ type ConnectionRedis interface {
 
 type Transport struct {
 	cfg        TransportConfig
-	producer   api.Producer
+	serializer api.Serializer
+	producer   api.RequestReplyProducer
 	consumer   api.Consumer
 	connection ConnectionRedis
 }
@@ -39,6 +41,7 @@ func NewTransport(cfg TransportConfig, serializer api.Serializer) (api.Transport
 
 	return &Transport{
 		cfg:        cfg,
+		serializer: serializer,
 		producer:   producer,
 		consumer:   consumer,
 		connection: connection,
@@ -53,6 +56,14 @@ func (t *Transport) Send(ctx context.Context, env api.Envelope) error {
 	return t.producer.Send(ctx, env)
 }
 
+func (t *Transport) Request(ctx context.Context, env api.Envelope) (api.Envelope, error) {
+	return t.producer.Request(ctx, env)
+}
+
+func (t *Transport) Reply(ctx context.Context, request api.Envelope, reply api.Envelope) error {
+	return t.producer.Reply(ctx, request, reply)
+}
+
 func (t *Transport) Receive(ctx context.Context, handler func(context.Context, api.Envelope) error) error {
 	return t.consumer.Consume(ctx, handler)
 }
@@ -61,6 +72,102 @@ func (t *Transport) Retry(ctx context.Context, env api.Envelope) error {
 	return t.producer.Send(ctx, env)
 }
 
+// List returns the messages of the stream followed by the ones still waiting in its
+// delayed set, so a failed message scheduled for later is visible as well.
+func (t *Transport) List(ctx context.Context, limit int) ([]api.Envelope, error) {
+	var messages []redis.XMessage
+	var err error
+
+	if limit > 0 {
+		messages, err = t.connection.Client().XRangeN(ctx, t.cfg.Options.Stream, "-", "+", int64(limit)).Result()
+	} else {
+		messages, err = t.connection.Client().XRange(ctx, t.cfg.Options.Stream, "-", "+").Result()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("redis: XRANGE failed: %w", err)
+	}
+
+	if limit <= 0 || len(messages) < limit {
+		_, delayed, errDelayed := listDelayed(ctx, t.connection.Client(), t.cfg.Options.Stream, limit-len(messages))
+		if errDelayed != nil {
+			return nil, errDelayed
+		}
+
+		for _, entry := range delayed {
+			messages = append(messages, entry.message())
+		}
+	}
+
+	envs := make([]api.Envelope, 0, len(messages))
+	for _, msg := range messages {
+		env, errDecode := decodeMessage(t.serializer, msg)
+		if errDecode != nil {
+			return nil, errDecode
+		}
+
+		envs = append(envs, env.WithStamp(stamps.TransportMessageIDStamp{ID: msg.ID}))
+	}
+
+	return envs, nil
+}
+
+func (t *Transport) Find(ctx context.Context, id string) (api.Envelope, error) {
+	_, entry, delayed, err := findDelayed(ctx, t.connection.Client(), t.cfg.Options.Stream, id)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := entry.message()
+	if !delayed {
+		messages, errRange := t.connection.Client().XRange(ctx, t.cfg.Options.Stream, id, id).Result()
+		if errRange != nil {
+			return nil, fmt.Errorf("redis: XRANGE failed: %w", errRange)
+		}
+
+		if len(messages) == 0 {
+			return nil, fmt.Errorf("redis: message '%s' not found", id)
+		}
+
+		msg = messages[0]
+	}
+
+	env, err := decodeMessage(t.serializer, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return env.WithStamp(stamps.TransportMessageIDStamp{ID: id}), nil
+}
+
+func (t *Transport) Remove(ctx context.Context, id string) error {
+	client := t.connection.Client()
+
+	member, _, delayed, err := findDelayed(ctx, client, t.cfg.Options.Stream, id)
+	if err != nil {
+		return err
+	}
+
+	var removed int64
+	if delayed {
+		removed, err = client.ZRem(ctx, delayedSetName(t.cfg.Options.Stream), member).Result()
+		if err != nil {
+			return fmt.Errorf("redis: ZREM failed: %w", err)
+		}
+	} else {
+		removed, err = client.XDel(ctx, t.cfg.Options.Stream, id).Result()
+		if err != nil {
+			return fmt.Errorf("redis: XDEL failed: %w", err)
+		}
+	}
+
+	if removed == 0 {
+		return fmt.Errorf("redis: message '%s' not found", id)
+	}
+
+	return nil
+}
+
 func (t *Transport) Setup(ctx context.Context) error {
 	if !t.cfg.Options.AutoSetup {
 		return nil
@@ -78,5 +185,5 @@ func (t *Transport) Setup(ctx context.Context) error {
 }
 
 func (t *Transport) Close() error {
-	return nil
+	return t.producer.Close()
 }
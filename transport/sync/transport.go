@@ -3,6 +3,7 @@ package sync
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/gerfey/messenger/api"
 	"github.com/gerfey/messenger/core/envelope"
@@ -18,24 +19,40 @@ func NewTransport(locator api.BusLocator) api.Transport {
 }
 
 func (t *Transport) Send(ctx context.Context, env api.Envelope) error {
+	_, err := t.dispatch(ctx, env)
+
+	return err
+}
+
+func (t *Transport) Request(ctx context.Context, env api.Envelope) (api.Envelope, error) {
+	return t.dispatch(ctx, env)
+}
+
+func (t *Transport) dispatch(ctx context.Context, env api.Envelope) (api.Envelope, error) {
 	busNameStump, ok := envelope.LastStampOf[stamps.BusNameStamp](env)
 	if !ok {
-		return errors.New("no BusNameStamp found in envelope")
+		return nil, errors.New("no BusNameStamp found in envelope")
 	}
 
 	messageBus, ok := t.locator.Get(busNameStump.Name)
 	if !ok {
-		return errors.New("no default transport")
+		return nil, errors.New("no default transport")
 	}
 
-	env = env.WithStamp(stamps.ReceivedStamp{Transport: t.Name()})
+	if delayStamp, hasDelay := envelope.LastStampOf[stamps.DelayStamp](env); hasDelay && delayStamp.Milliseconds > 0 {
+		timer := time.NewTimer(delayStamp.Duration())
+		defer timer.Stop()
 
-	_, err := messageBus.Dispatch(ctx, env)
-	if err != nil {
-		return err
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
 	}
 
-	return nil
+	env = env.WithStamp(stamps.ReceivedStamp{Transport: t.Name()})
+
+	return messageBus.Dispatch(ctx, env)
 }
 
 func (t *Transport) Receive(_ context.Context, _ func(context.Context, api.Envelope) error) error {
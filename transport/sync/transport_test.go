@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -112,6 +113,50 @@ func TestTransport_Send_DispatchError(t *testing.T) {
 	assert.Equal(t, dispatchErr, err)
 }
 
+func TestTransport_Send_WithDelay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLocator := mocks.NewMockBusLocator(ctrl)
+	mockBus := mocks.NewMockMessageBus(ctrl)
+	transport := sync.NewTransport(mockLocator)
+
+	env := envelope.NewEnvelope(&testMessage{content: "test message"}).
+		WithStamp(stamps.BusNameStamp{Name: "test-bus"}).
+		WithStamp(stamps.NewDelayStamp(30 * time.Millisecond))
+
+	mockLocator.EXPECT().Get("test-bus").Return(mockBus, true)
+	mockBus.EXPECT().Dispatch(gomock.Any(), gomock.Any(), gomock.Any()).Return(env, nil)
+
+	start := time.Now()
+	err := transport.Send(t.Context(), env)
+
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+}
+
+func TestTransport_Send_WithDelayCancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLocator := mocks.NewMockBusLocator(ctrl)
+	mockBus := mocks.NewMockMessageBus(ctrl)
+	transport := sync.NewTransport(mockLocator)
+
+	env := envelope.NewEnvelope(&testMessage{content: "test message"}).
+		WithStamp(stamps.BusNameStamp{Name: "test-bus"}).
+		WithStamp(stamps.NewDelayStamp(time.Minute))
+
+	mockLocator.EXPECT().Get("test-bus").Return(mockBus, true)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	err := transport.Send(ctx, env)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
 func TestTransport_Receive(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()